@@ -0,0 +1,368 @@
+// This file provides support for having HiGHS invoke Go functions while it
+// solves a model.
+
+package highs
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+// #include "highs-externs.h"
+// extern void goHighsCallback(int, char*, HighsCallbackDataOut*, HighsCallbackDataIn*, void*);
+import "C"
+
+// An IncumbentSolution represents an improving solution found by HiGHS while
+// solving a mixed-integer model.
+type IncumbentSolution struct {
+	Objective    float64   // Objective value of the incumbent
+	DualBound    float64   // Best dual bound at the time the incumbent was found
+	Gap          float64   // Relative MIP gap at the time the incumbent was found
+	ColumnPrimal []float64 // Primal column values of the incumbent
+}
+
+// A callbackSet holds the Go functions that HiGHS should invoke on behalf of
+// a single RawModel.  A nil function disables the corresponding callback.
+type callbackSet struct {
+	interrupt     func() bool                  // Return true to interrupt the solve
+	mipImproving  func(IncumbentSolution) bool // Return false to interrupt the solve
+	objectiveOnly bool                         // true=mipImproving ignores ColumnPrimal
+	logging       func(level int, msg string)  // Receive a line of log output
+	finish        func()                       // Invoke when a solve finishes
+	stop          *atomic.Bool                 // true=a callback requested termination
+}
+
+// callbackRegistry maps each HiGHS object for which callbacks have been
+// requested to the corresponding callbackSet.  HiGHS passes the object back
+// to goHighsCallback as user data, which avoids having to pass Go pointers to
+// C.
+var callbackRegistry = struct {
+	sync.Mutex
+	sets map[unsafe.Pointer]*callbackSet
+}{sets: make(map[unsafe.Pointer]*callbackSet)}
+
+// lookupCallbacks returns a copy of the callbackSet associated with a HiGHS
+// object and an indication of whether there is one.  Returning a copy lets
+// HiGHS invoke the callbacks while another goroutine replaces them.
+func lookupCallbacks(obj unsafe.Pointer) (callbackSet, bool) {
+	callbackRegistry.Lock()
+	defer callbackRegistry.Unlock()
+	cbs, ok := callbackRegistry.sets[obj]
+	if !ok {
+		return callbackSet{}, false
+	}
+	return *cbs, true
+}
+
+// forgetCallbacks removes the callbackSet associated with a HiGHS object.  It
+// must be called before the object is destroyed.
+func forgetCallbacks(obj unsafe.Pointer) {
+	callbackRegistry.Lock()
+	defer callbackRegistry.Unlock()
+	delete(callbackRegistry.sets, obj)
+}
+
+// resetCallbacks clears any termination request left over from a previous
+// solve.
+func resetCallbacks(obj unsafe.Pointer) {
+	callbackRegistry.Lock()
+	defer callbackRegistry.Unlock()
+	if cbs, ok := callbackRegistry.sets[obj]; ok {
+		cbs.stop.Store(false)
+	}
+}
+
+// finishCallbacks informs a model's callbackSet that a solve has finished.
+func finishCallbacks(obj unsafe.Pointer) {
+	cbs, ok := lookupCallbacks(obj)
+	if ok && cbs.finish != nil {
+		cbs.finish()
+	}
+}
+
+// updateCallbacks applies a function to a model's callbackSet then tells
+// HiGHS which types of callback are now of interest.
+func (m *RawModel) updateCallbacks(update func(cbs *callbackSet)) error {
+	// Modify the model's callbackSet, creating it if necessary.
+	callbackRegistry.Lock()
+	cbs, ok := callbackRegistry.sets[m.obj]
+	if !ok {
+		cbs = &callbackSet{stop: new(atomic.Bool)}
+		callbackRegistry.sets[m.obj] = cbs
+	}
+	update(cbs)
+	wantInterrupt := cbs.interrupt != nil || cbs.mipImproving != nil
+	want := map[C.HighsInt]bool{
+		C.kHighsCallbackSimplexInterrupt:     wantInterrupt,
+		C.kHighsCallbackIpmInterrupt:         wantInterrupt,
+		C.kHighsCallbackMipInterrupt:         wantInterrupt,
+		C.kHighsCallbackMipImprovingSolution: cbs.mipImproving != nil,
+		C.kHighsCallbackLogging:              cbs.logging != nil,
+	}
+	callbackRegistry.Unlock()
+
+	// Register goHighsCallback the first time through.
+	if !ok {
+		status := C.Highs_setCallback(m.obj,
+			C.HighsCCallbackType(C.goHighsCallback), m.obj)
+		err := m.recordStatus(status, "Highs_setCallback", "updateCallbacks")
+		if err != nil {
+			return err
+		}
+	}
+
+	// Start or stop each type of callback.
+	for cbType, on := range want {
+		if on {
+			status := C.Highs_startCallback(m.obj, C.int(cbType))
+			err := m.recordStatus(status, "Highs_startCallback", "updateCallbacks")
+			if err != nil {
+				return err
+			}
+		} else {
+			status := C.Highs_stopCallback(m.obj, C.int(cbType))
+			err := m.recordStatus(status, "Highs_stopCallback", "updateCallbacks")
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// goHighsCallback is invoked by HiGHS for every type of callback that has
+// been started.  It dispatches to the appropriate Go function.
+//
+//export goHighsCallback
+func goHighsCallback(cbType C.int, msg *C.char, dataOut *C.HighsCallbackDataOut, dataIn *C.HighsCallbackDataIn, userData unsafe.Pointer) {
+	cbs, ok := lookupCallbacks(userData)
+	if !ok {
+		return
+	}
+	switch C.HighsInt(cbType) {
+	case C.kHighsCallbackSimplexInterrupt, C.kHighsCallbackIpmInterrupt, C.kHighsCallbackMipInterrupt:
+		// Interrupt the solve if requested to do so.
+		if cbs.stop.Load() || (cbs.interrupt != nil && cbs.interrupt()) {
+			dataIn.user_interrupt = 1
+		}
+
+	case C.kHighsCallbackLogging:
+		// Pass the log message to Go.
+		if cbs.logging != nil {
+			cbs.logging(int(dataOut.log_type), strings.TrimSuffix(C.GoString(msg), "\n"))
+		}
+
+	case C.kHighsCallbackMipImprovingSolution:
+		// Pass the new incumbent to Go.
+		if cbs.mipImproving == nil {
+			return
+		}
+		inc := IncumbentSolution{
+			Objective: float64(dataOut.objective_function_value),
+			DualBound: float64(dataOut.mip_dual_bound),
+			Gap:       float64(dataOut.mip_gap),
+		}
+		if dataOut.mip_solution != nil && !cbs.objectiveOnly {
+			nc := int(C.Highs_getNumCol(userData))
+			inc.ColumnPrimal = convertSlice[float64, C.double](unsafe.Slice(dataOut.mip_solution, nc))
+		}
+		if !cbs.mipImproving(inc) {
+			// HiGHS may ignore an interrupt request from this
+			// callback so we also issue the request from the next
+			// interrupt callback.
+			cbs.stop.Store(true)
+			dataIn.user_interrupt = 1
+		}
+	}
+}
+
+// SolveStream solves a model in a separate goroutine.  Each improving
+// solution HiGHS finds while solving a mixed-integer model is sent on the
+// first returned channel, which is closed when the solve completes.  The
+// second channel receives the error, if any, produced by the solve and is then
+// closed.  Cancelling ctx interrupts the solve, in which case the error channel
+// receives ctx.Err().  The model must not otherwise be used until the error
+// channel is closed.
+func (m *RawModel) SolveStream(ctx context.Context) (<-chan IncumbentSolution, <-chan error) {
+	incs := make(chan IncumbentSolution)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		err := m.solveStream(ctx, incs)
+		close(incs)
+		if err != nil {
+			errs <- err
+		}
+	}()
+	return incs, errs
+}
+
+// solveStream is a helper function for SolveStream that solves the model
+// while sending incumbents on a channel.
+func (m *RawModel) solveStream(ctx context.Context, incs chan<- IncumbentSolution) error {
+	// Install callbacks that forward incumbents and watch for cancellation
+	// in addition to any existing interrupt callback.  Suppress any existing
+	// finish function, which belongs to the replaced MIP-solution callback.
+	var prev callbackSet
+	err := m.updateCallbacks(func(cbs *callbackSet) {
+		prev = *cbs
+		cbs.interrupt = func() bool {
+			return ctx.Err() != nil || (prev.interrupt != nil && prev.interrupt())
+		}
+		cbs.finish = nil
+		cbs.objectiveOnly = false
+		cbs.mipImproving = func(inc IncumbentSolution) bool {
+			select {
+			case incs <- inc:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	// Solve the model then restore the previous callbacks.
+	_, err = m.Solve()
+	rErr := m.updateCallbacks(func(cbs *callbackSet) { *cbs = prev })
+	switch {
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case err != nil:
+		return err
+	default:
+		return rErr
+	}
+}
+
+// SolveContext is a variant of Solve that interrupts the solve if ctx is
+// cancelled or its deadline passes, which lets callers enforce deadlines more
+// precisely than with SetTimeLimit.  If the solve is interrupted,
+// SolveContext returns whatever partial solution HiGHS produced along with
+// ctx.Err().
+func (m *RawModel) SolveContext(ctx context.Context) (*RawSolution, error) {
+	// Install an interrupt callback that watches for cancellation in
+	// addition to any existing interrupt callback.
+	var prev callbackSet
+	err := m.updateCallbacks(func(cbs *callbackSet) {
+		prev = *cbs
+		cbs.interrupt = func() bool {
+			return ctx.Err() != nil || (prev.interrupt != nil && prev.interrupt())
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Solve the model then restore the previous callbacks.
+	soln, err := m.Solve()
+	rErr := m.updateCallbacks(func(cbs *callbackSet) { *cbs = prev })
+	switch {
+	case ctx.Err() != nil:
+		return soln, ctx.Err()
+	case err != nil:
+		return soln, err
+	default:
+		return soln, rErr
+	}
+}
+
+// SetLogCallback arranges for HiGHS to pass each line of its log output to
+// fn instead of writing it to standard output.  The level argument is
+// HiGHS's log type (1=info, 2=detailed, 3=verbose, 4=warning, 5=error), and
+// msg lacks a trailing newline.  Because HiGHS produces no log output when
+// the output_flag option is false, as it is by default (see DefaultSilent),
+// SetLogCallback sets output_flag to true.  Passing a nil fn removes the
+// callback but leaves output_flag unchanged.
+func (m *RawModel) SetLogCallback(fn func(level int, msg string)) error {
+	if fn != nil {
+		err := m.SetBoolOption("output_flag", true)
+		if err != nil {
+			return err
+		}
+	}
+	return m.updateCallbacks(func(cbs *callbackSet) { cbs.logging = fn })
+}
+
+// SetMipSolutionCallback arranges for HiGHS to invoke fn with the objective
+// value and column values of each improving solution it finds while solving
+// a mixed-integer model.  If fn returns false, HiGHS stops the solve, which
+// lets the caller implement stopping rules beyond those provided by the gap
+// and time-limit options.  If minInterval is positive, fn is invoked at most
+// once per minInterval; improving solutions found in between are skipped
+// except that the best of them is delivered when the solve finishes.  Passing
+// a nil fn removes the callback.
+func (m *RawModel) SetMipSolutionCallback(fn func(objective float64, solution []float64) bool, minInterval time.Duration) error {
+	return m.updateCallbacks(func(cbs *callbackSet) {
+		cbs.objectiveOnly = false
+		if fn == nil {
+			cbs.mipImproving = nil
+			cbs.finish = nil
+			return
+		}
+		deliver := func(inc IncumbentSolution) bool {
+			return fn(inc.Objective, inc.ColumnPrimal)
+		}
+		cbs.mipImproving, cbs.finish = throttleIncumbents(deliver, minInterval, time.Now)
+	})
+}
+
+// SetMipObjectiveCallback arranges for HiGHS to invoke fn with the objective
+// value of each improving solution it finds while solving a mixed-integer
+// model and the best dual bound at that time.  Unlike SetMipSolutionCallback,
+// it does not copy the incumbent's column values, which makes it inexpensive
+// for tracking the objective's progress on large models.  It replaces any
+// callback installed by SetMipSolutionCallback and vice versa.  Passing a nil
+// fn removes the callback.
+func (m *RawModel) SetMipObjectiveCallback(fn func(obj, bound float64)) error {
+	return m.updateCallbacks(func(cbs *callbackSet) {
+		cbs.finish = nil
+		if fn == nil {
+			cbs.mipImproving = nil
+			cbs.objectiveOnly = false
+			return
+		}
+		cbs.objectiveOnly = true
+		cbs.mipImproving = func(inc IncumbentSolution) bool {
+			fn(inc.Objective, inc.DualBound)
+			return true
+		}
+	})
+}
+
+// throttleIncumbents wraps a function that receives incumbents so that it is
+// invoked at most once per minInterval, as measured by now.  It returns the
+// wrapped function and a function to call when the solve finishes, which
+// delivers the most recent skipped incumbent, if any.  A nonpositive
+// minInterval disables throttling.
+func throttleIncumbents(deliver func(IncumbentSolution) bool, minInterval time.Duration, now func() time.Time) (func(IncumbentSolution) bool, func()) {
+	if minInterval <= 0 {
+		return deliver, nil
+	}
+	var last time.Time             // Time of the most recent delivery
+	var pending *IncumbentSolution // Most recent skipped incumbent
+	stopped := false               // true=deliver requested termination
+	throttled := func(inc IncumbentSolution) bool {
+		t := now()
+		if !last.IsZero() && t.Sub(last) < minInterval {
+			pending = &inc
+			return true
+		}
+		last, pending = t, nil
+		stopped = !deliver(inc)
+		return !stopped
+	}
+	finish := func() {
+		if pending != nil && !stopped {
+			deliver(*pending)
+		}
+		last, pending, stopped = time.Time{}, nil, false
+	}
+	return throttled, finish
+}
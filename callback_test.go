@@ -0,0 +1,340 @@
+// This file tests the highs package's support for solver callbacks.
+
+package highs
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// knapsackModel is a helper function that constructs a 0-1 knapsack problem
+// with enough items that HiGHS is likely to find more than one incumbent.  The
+// model is expressed as a minimization of the negated item values.  Its
+// optimal objective value is -56.
+func knapsackModel() *Model {
+	values := []float64{10, 13, 7, 8, 15, 4, 9, 12, 6, 11, 5, 14}
+	weights := []float64{5, 7, 4, 5, 8, 3, 5, 7, 3, 6, 3, 8}
+	var model Model
+	model.ColCosts = make([]float64, len(values))
+	model.ColLower = make([]float64, len(values))
+	model.ColUpper = make([]float64, len(values))
+	model.VarTypes = make([]VariableType, len(values))
+	for i, v := range values {
+		model.ColCosts[i] = -v
+		model.ColUpper[i] = 1.0
+		model.VarTypes[i] = IntegerType
+	}
+	model.AddDenseRow(math.Inf(-1), weights, 30.0)
+	return &model
+}
+
+// multiKnapsackModel is a helper function that constructs a maximization
+// problem over n binary columns subject to r knapsack constraints.  Models
+// with a few dozen columns take HiGHS a noticeable amount of time to solve
+// when presolve is disabled.
+func multiKnapsackModel(n, r int) *Model {
+	var model Model
+	model.Maximize = true
+	model.ColCosts = make([]float64, n)
+	model.ColLower = make([]float64, n)
+	model.ColUpper = make([]float64, n)
+	model.VarTypes = make([]VariableType, n)
+	for c := 0; c < n; c++ {
+		model.ColCosts[c] = float64(10 + (c*37)%23)
+		model.ColUpper[c] = 1.0
+		model.VarTypes[c] = IntegerType
+	}
+	for i := 0; i < r; i++ {
+		coeffs := make([]float64, n)
+		for c := range coeffs {
+			coeffs[c] = float64(5 + (i*c*13+c*7+i)%17)
+		}
+		model.AddDenseRow(math.Inf(-1), coeffs, 150.0*float64(n)/60.0)
+	}
+	return &model
+}
+
+// TestSolveStream tests that SolveStream delivers a sequence of improving
+// incumbents followed by the optimum.
+func TestSolveStream(t *testing.T) {
+	// Prepare the model.  Disable presolve to give HiGHS more work to do.
+	raw, err := knapsackModel().ToRawModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+	checkErr(t, raw.SetStringOption("presolve", "off"))
+
+	// Consume incumbents until the channel closes.
+	incs, errs := raw.SolveStream(context.Background())
+	n := 0
+	best := math.Inf(1)
+	for inc := range incs {
+		if len(inc.ColumnPrimal) != 12 {
+			t.Fatalf("expected 12 primal values but saw %d", len(inc.ColumnPrimal))
+		}
+		if inc.Objective > best {
+			t.Fatalf("incumbent objective worsened from %v to %v", best, inc.Objective)
+		}
+		best = inc.Objective
+		n++
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("Received %d incumbent(s)", n)
+
+	// Ensure the final incumbent is optimal.
+	if n == 0 {
+		t.Fatal("no incumbents were received")
+	}
+	if math.Abs(best+56.0) > 1e-6 {
+		t.Fatalf("final incumbent had objective %v but should have had -56", best)
+	}
+}
+
+// TestSolveStreamChaining tests that SolveStream consults an existing
+// interrupt callback, suppresses an existing finish function, and restores
+// both when the solve completes.
+func TestSolveStreamChaining(t *testing.T) {
+	raw := mustToRawModel(t, knapsackModel())
+	checkErr(t, raw.SetStringOption("presolve", "off"))
+	interrupts, finishes := 0, 0
+	checkErr(t, raw.updateCallbacks(func(cbs *callbackSet) {
+		cbs.interrupt = func() bool {
+			interrupts++
+			return false
+		}
+		cbs.finish = func() { finishes++ }
+	}))
+	incs, errs := raw.SolveStream(context.Background())
+	for range incs {
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	if interrupts == 0 {
+		t.Fatal("the existing interrupt callback was not consulted")
+	}
+	if finishes != 0 {
+		t.Fatalf("the existing finish function was invoked %d time(s)", finishes)
+	}
+	cbs, _ := lookupCallbacks(raw.obj)
+	if cbs.finish == nil || cbs.interrupt == nil {
+		t.Fatal("the existing callbacks were not restored")
+	}
+}
+
+// TestSolveContext tests that cancelling a context interrupts a slow solve.
+func TestSolveContext(t *testing.T) {
+	// Cancel the solve shortly after it starts.
+	raw := mustToRawModel(t, multiKnapsackModel(300, 10))
+	checkErr(t, raw.SetStringOption("presolve", "off"))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	soln, err := raw.SolveContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded but saw %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("SolveContext took %v to return after cancellation", elapsed)
+	}
+	if soln != nil && soln.Status == Optimal {
+		t.Fatal("expected a non-optimal status from an interrupted solve")
+	}
+
+	// An uncancelled context does not interfere with solving.
+	raw = mustToRawModel(t, knapsackModel())
+	soln, err = raw.SolveContext(context.Background())
+	checkErr(t, err)
+	if soln.Objective != -56.0 {
+		t.Fatalf("expected objective -56 but saw %v", soln.Objective)
+	}
+}
+
+// TestSetLogCallback tests that HiGHS's log output is delivered to a Go
+// function.
+func TestSetLogCallback(t *testing.T) {
+	raw := mustToRawModel(t, minimalAPIModel(false))
+	var msgs []string
+	checkErr(t, raw.SetLogCallback(func(level int, msg string) {
+		msgs = append(msgs, msg)
+	}))
+	_, err := raw.Solve()
+	checkErr(t, err)
+	if len(msgs) == 0 {
+		t.Fatal("no log messages were received")
+	}
+
+	// Removing the callback stops the delivery of log messages.
+	checkErr(t, raw.SetLogCallback(nil))
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+	n := len(msgs)
+	_, err = raw.Solve()
+	checkErr(t, err)
+	if len(msgs) != n {
+		t.Fatalf("received %d log messages after removing the callback", len(msgs)-n)
+	}
+}
+
+// TestSetMipSolutionCallback tests that a MIP-solution callback can stop a
+// solve once an incumbent is good enough.
+func TestSetMipSolutionCallback(t *testing.T) {
+	// Stop as soon as an incumbent's objective reaches a threshold.
+	const threshold = -40.0
+	raw := mustToRawModel(t, knapsackModel())
+	checkErr(t, raw.SetStringOption("presolve", "off"))
+	var lastObj float64
+	var lastX []float64
+	checkErr(t, raw.SetMipSolutionCallback(func(obj float64, x []float64) bool {
+		lastObj = obj
+		lastX = x
+		return obj > threshold
+	}, 0))
+	soln, err := raw.Solve()
+	checkErr(t, err)
+	if lastX == nil {
+		t.Fatal("no incumbents were received")
+	}
+	if lastObj > threshold {
+		t.Fatalf("the last incumbent's objective, %v, never crossed %v", lastObj, threshold)
+	}
+
+	// Confirm that the solve returned the last incumbent.
+	if math.Abs(soln.Objective-lastObj) > 1e-6 {
+		t.Fatalf("expected objective %v but saw %v", lastObj, soln.Objective)
+	}
+	compSlices(t, "ColumnPrimal", roundFloats(1e-6, soln.ColumnPrimal), roundFloats(1e-6, lastX))
+}
+
+// TestSetMipObjectiveCallback tests that SetMipObjectiveCallback reports each
+// incumbent's objective value and bound without copying its column values.
+func TestSetMipObjectiveCallback(t *testing.T) {
+	raw := mustToRawModel(t, multiKnapsackModel(200, 10))
+	checkErr(t, raw.SetStringOption("presolve", "off"))
+	checkErr(t, raw.SetTimeLimit(2.0))
+	var objs, bounds []float64
+	checkErr(t, raw.SetMipObjectiveCallback(func(obj, bound float64) {
+		objs = append(objs, obj)
+		bounds = append(bounds, bound)
+	}))
+
+	// Interpose on the installed callback to observe the incumbents
+	// HiGHS passes to it.
+	copied := false
+	checkErr(t, raw.updateCallbacks(func(cbs *callbackSet) {
+		fn := cbs.mipImproving
+		cbs.mipImproving = func(inc IncumbentSolution) bool {
+			copied = copied || inc.ColumnPrimal != nil
+			return fn(inc)
+		}
+	}))
+	soln, err := raw.Solve()
+	checkErr(t, err)
+	if len(objs) == 0 {
+		t.Fatal("no incumbents were received")
+	}
+	if copied {
+		t.Fatal("column values were copied for an objective-only callback")
+	}
+	for i, obj := range objs {
+		if bounds[i] < obj-1e-6 {
+			t.Fatalf("incumbent %d has objective %v above its bound %v", i, obj, bounds[i])
+		}
+	}
+	if last := objs[len(objs)-1]; math.Abs(last-soln.Objective) > 1e-6 {
+		t.Fatalf("expected the final incumbent to have objective %v but saw %v",
+			soln.Objective, last)
+	}
+}
+
+// TestThrottleIncumbents tests that incumbents are delivered at most once per
+// interval and that the final incumbent is always delivered.
+func TestThrottleIncumbents(t *testing.T) {
+	// Deliver incumbents every 10ms with a 35ms interval.
+	var clock time.Time
+	now := func() time.Time { return clock }
+	var got []float64
+	deliver := func(inc IncumbentSolution) bool {
+		got = append(got, inc.Objective)
+		return true
+	}
+	throttled, finish := throttleIncumbents(deliver, 35*time.Millisecond, now)
+	clock = time.Unix(1000, 0)
+	for i := 0; i < 10; i++ {
+		if !throttled(IncumbentSolution{Objective: float64(-i)}) {
+			t.Fatal("a throttled incumbent requested termination")
+		}
+		clock = clock.Add(10 * time.Millisecond)
+	}
+	finish()
+	compSlices(t, "delivered", got, []float64{0, -4, -8, -9})
+}
+
+// TestMipSolutionCallbackInterval tests that a long minimum interval limits
+// the number of incumbents delivered during a real solve.
+func TestMipSolutionCallbackInterval(t *testing.T) {
+	raw := mustToRawModel(t, knapsackModel())
+	checkErr(t, raw.SetStringOption("presolve", "off"))
+	n := 0
+	last := math.Inf(1)
+	checkErr(t, raw.SetMipSolutionCallback(func(obj float64, x []float64) bool {
+		n++
+		last = obj
+		return true
+	}, time.Hour))
+	soln, err := raw.Solve()
+	checkErr(t, err)
+	if n == 0 || n > 2 {
+		t.Fatalf("expected 1 or 2 incumbents to be delivered but saw %d", n)
+	}
+	if math.Abs(last-soln.Objective) > 1e-6 {
+		t.Fatalf("expected the final incumbent to have objective %v but saw %v", soln.Objective, last)
+	}
+}
+
+// TestSwapCallbacksDuringSolve replaces a model's callbacks from one goroutine
+// while HiGHS invokes them from another.  Run it with -race to detect
+// unsynchronized access to the callback registry.
+func TestSwapCallbacksDuringSolve(t *testing.T) {
+	raw := mustToRawModel(t, multiKnapsackModel(200, 10))
+	checkErr(t, raw.SetStringOption("presolve", "off"))
+	checkErr(t, raw.SetTimeLimit(1.0))
+	var calls atomic.Int64
+	checkErr(t, raw.SetMipObjectiveCallback(func(obj, bound float64) { calls.Add(1) }))
+	checkErr(t, raw.SetLogCallback(func(level int, msg string) { calls.Add(1) }))
+
+	// Solve the model in the background.
+	done := make(chan error)
+	go func() {
+		_, err := raw.Solve()
+		done <- err
+	}()
+
+	// Repeatedly swap callbacks until the solve finishes.
+	for i := 0; ; i++ {
+		select {
+		case err := <-done:
+			checkErr(t, err)
+			if calls.Load() == 0 {
+				t.Fatal("no callbacks were invoked")
+			}
+			return
+		default:
+		}
+		if i%2 == 0 {
+			checkErr(t, raw.SetMipSolutionCallback(func(obj float64, x []float64) bool {
+				calls.Add(1)
+				return true
+			}, 0))
+		} else {
+			checkErr(t, raw.SetMipObjectiveCallback(func(obj, bound float64) { calls.Add(1) }))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -55,11 +55,12 @@ func ExampleModel_AddDenseRow() {
 	// ConstMatrix: [{0 0 1} {0 1 -1} {1 1 1} {1 2 -1} {2 2 1} {2 3 -1}]
 }
 
-// Low-level models default to writing verbose status messages.  SetBoolOption
-// can be used to disable these.
+// Unless DefaultSilent is set to false, low-level models are created with
+// HiGHS's verbose status messages disabled.  SetBoolOption can be used to
+// re-enable these.
 func ExampleRawModel_SetBoolOption() {
 	m := highs.NewRawModel()
-	m.SetBoolOption("output_flag", false)
+	m.SetBoolOption("output_flag", true)
 }
 
 // Here is a complete example of using the highs package's high-level interface
@@ -108,7 +109,7 @@ func ExampleModel_Solve() {
 // and B is exactly twice the difference in face value between B and C, where B
 // is strictly greater than C?
 //
-// Remove the SetBoolOption line to view HiGHS status output.
+// Change false to true in the SetBoolOption line to view HiGHS status output.
 func ExampleRawModel_Solve() {
 	// Define a function that panics on error.
 	checkErr := func(err error) {
@@ -161,3 +162,27 @@ func ExampleRawModel_Solve() {
 	// C: 3
 	// Total face value: 13
 }
+
+// This example shows how to read the shadow price of a binding constraint and
+// the reduced cost of a column.  The model minimizes 2x + 3y subject to
+// x + y ≥ 4 with x, y ≥ 0.  Each unit increase in the constraint's right-hand
+// side increases the optimal cost by 2, and each unit of y forced into the
+// solution increases the cost by 3 − 2 = 1.
+func ExampleSolution_ShadowPrices() {
+	// Prepare and solve the model.
+	var m highs.Model
+	m.ColCosts = []float64{2.0, 3.0}
+	m.ColLower = []float64{0.0, 0.0}
+	m.AddDenseRow(4.0, []float64{1.0, 1.0}, math.Inf(1))
+	soln, err := m.Solve()
+	if err != nil {
+		panic(err)
+	}
+
+	// Output the constraint's shadow price and y's reduced cost.
+	fmt.Printf("Shadow price of x + y ≥ 4: %.1f\n", soln.ShadowPrices()[0])
+	fmt.Printf("Reduced cost of y: %.1f\n", soln.ReducedCosts()[1])
+	// Output:
+	// Shadow price of x + y ≥ 4: 2.0
+	// Reduced cost of y: 1.0
+}
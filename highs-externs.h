@@ -8,6 +8,7 @@
 #define _EXTERNS_H_
 
 #include "util/HighsInt.h"
+#include "lp_data/HighsCallbackStruct.h"
 
 extern const HighsInt kHighsStatusError;
 extern const HighsInt kHighsStatusOk;
@@ -48,6 +49,8 @@ extern const HighsInt kHighsModelStatusObjectiveTarget;
 extern const HighsInt kHighsModelStatusTimeLimit;
 extern const HighsInt kHighsModelStatusIterationLimit;
 extern const HighsInt kHighsModelStatusUnknown;
+extern const HighsInt kHighsModelStatusSolutionLimit;
+extern const HighsInt kHighsModelStatusInterrupt;
 
 extern const HighsInt kHighsBasisStatusLower;
 extern const HighsInt kHighsBasisStatusBasic;
@@ -55,6 +58,14 @@ extern const HighsInt kHighsBasisStatusUpper;
 extern const HighsInt kHighsBasisStatusZero;
 extern const HighsInt kHighsBasisStatusNonbasic;
 
+extern const HighsInt kHighsCallbackLogging;
+extern const HighsInt kHighsCallbackSimplexInterrupt;
+extern const HighsInt kHighsCallbackIpmInterrupt;
+extern const HighsInt kHighsCallbackMipSolution;
+extern const HighsInt kHighsCallbackMipImprovingSolution;
+extern const HighsInt kHighsCallbackMipLogging;
+extern const HighsInt kHighsCallbackMipInterrupt;
+
 extern
 HighsInt Highs_passModel(void* highs, const HighsInt num_col,
                          const HighsInt num_row, const HighsInt num_nz,
@@ -86,4 +97,50 @@ HighsInt Highs_writeSolution(const void* highs, const char* filename);
 extern
 HighsInt Highs_writeSolutionPretty(const void* highs, const char* filename);
 
+extern
+double Highs_getRunTime(const void* highs);
+
+extern
+HighsInt Highs_setCallback(void* highs, HighsCCallbackType user_callback,
+                           void* user_callback_data);
+
+extern
+HighsInt Highs_startCallback(void* highs, const int callback_type);
+
+extern
+HighsInt Highs_stopCallback(void* highs, const int callback_type);
+
+extern
+HighsInt Highs_getNumCol(const void* highs);
+
+extern
+HighsInt Highs_getDualRay(const void* highs, HighsInt* has_dual_ray,
+                          double* dual_ray_value);
+
+extern
+HighsInt Highs_getPrimalRay(const void* highs, HighsInt* has_primal_ray,
+                            double* primal_ray_value);
+
+extern
+HighsInt Highs_getRanging(
+    void* highs,
+    double* col_cost_up_value, double* col_cost_up_objective,
+    HighsInt* col_cost_up_in_var, HighsInt* col_cost_up_ou_var,
+    double* col_cost_dn_value, double* col_cost_dn_objective,
+    HighsInt* col_cost_dn_in_var, HighsInt* col_cost_dn_ou_var,
+    double* col_bound_up_value, double* col_bound_up_objective,
+    HighsInt* col_bound_up_in_var, HighsInt* col_bound_up_ou_var,
+    double* col_bound_dn_value, double* col_bound_dn_objective,
+    HighsInt* col_bound_dn_in_var, HighsInt* col_bound_dn_ou_var,
+    double* row_bound_up_value, double* row_bound_up_objective,
+    HighsInt* row_bound_up_in_var, HighsInt* row_bound_up_ou_var,
+    double* row_bound_dn_value, double* row_bound_dn_objective,
+    HighsInt* row_bound_dn_in_var, HighsInt* row_bound_dn_ou_var);
+
+extern
+HighsInt Highs_scaleCol(void* highs, const HighsInt col, const double scaleval);
+
+extern
+HighsInt Highs_scaleRow(void* highs, const HighsInt row, const double scaleval);
+
 #endif
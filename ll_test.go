@@ -3,7 +3,10 @@
 package highs
 
 import (
+	"bytes"
+	"errors"
 	"math"
+	"strings"
 	"testing"
 )
 
@@ -155,3 +158,852 @@ func TestFullAPIQPMin(t *testing.T) {
 		t.Fatalf("objective value was %.2f but should have been -5.25", soln.Objective)
 	}
 }
+
+// TestDefaultSilent tests that DefaultSilent controls whether new models
+// produce status output.
+func TestDefaultSilent(t *testing.T) {
+	// By default, new models should be silent.
+	for _, model := range []*RawModel{NewRawModel(), mustToRawModel(t, minimalAPIModel(false))} {
+		out, err := model.GetBoolOption("output_flag")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out {
+			t.Fatal("expected output_flag to be false by default")
+		}
+	}
+
+	// Models created with DefaultSilent=false should not be silent.
+	DefaultSilent = false
+	defer func() { DefaultSilent = true }()
+	out, err := NewRawModel().GetBoolOption("output_flag")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !out {
+		t.Fatal("expected output_flag to be true when DefaultSilent is false")
+	}
+}
+
+// TestTightenRowBound tests that TightenRowBound correctly reports whether the
+// TestMinimalAPIMin model remains feasible as its first row is tightened.
+func TestTightenRowBound(t *testing.T) {
+	raw := mustToRawModel(t, minimalAPIModel(false))
+	for _, tc := range []struct {
+		upper    float64
+		feasible bool
+	}{
+		{2.25, true}, // Current x_1 value
+		{1.0, true},  // x_1's lower bound
+		{0.5, false}, // Below x_1's lower bound
+	} {
+		feas, err := raw.TightenRowBound(0, math.Inf(-1), tc.upper)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if feas != tc.feasible {
+			t.Fatalf("expected feasible=%v for x_1 <= %v but saw %v",
+				tc.feasible, tc.upper, feas)
+		}
+	}
+}
+
+// TestRepairBasis tests that a model to which a row was added re-solves in
+// fewer simplex iterations after RepairBasis than an identical model solved
+// from scratch.
+func TestRepairBasis(t *testing.T) {
+	// Solve the TestMinimalAPIMin model, add a non-binding row, repair the
+	// basis, and re-solve.
+	warm := mustToRawModel(t, minimalAPIModel(false))
+	checkErr(t, warm.SetStringOption("presolve", "off"))
+	_, err := warm.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkErr(t, warm.AddDenseRow(math.Inf(-1), []float64{1.0, 1.0}, 10.0))
+	checkErr(t, warm.RepairBasis())
+	warmSoln, err := warm.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	warmIters, err := warmSoln.GetIntInfo("simplex_iteration_count")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Solve the same model from scratch.
+	model := minimalAPIModel(false)
+	model.AddDenseRow(math.Inf(-1), []float64{1.0, 1.0}, 10.0)
+	cold := mustToRawModel(t, model)
+	checkErr(t, cold.SetStringOption("presolve", "off"))
+	coldSoln, err := cold.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	coldIters, err := coldSoln.GetIntInfo("simplex_iteration_count")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Compare the two solves.
+	if math.Abs(warmSoln.Objective-coldSoln.Objective) > 1e-9 {
+		t.Fatalf("warm objective (%v) differs from cold objective (%v)",
+			warmSoln.Objective, coldSoln.Objective)
+	}
+	if warmIters >= coldIters {
+		t.Fatalf("warm start took %d iterations, cold start took %d",
+			warmIters, coldIters)
+	}
+}
+
+// TestChangeColsIntegralityByRange tests that making x_1 in the
+// TestMinimalAPIMax model integral yields an integral optimum and that a
+// mismatched range is rejected.
+func TestChangeColsIntegralityByRange(t *testing.T) {
+	raw := mustToRawModel(t, minimalAPIModel(true))
+	err := raw.ChangeColsIntegralityByRange(0, 1, []VariableType{IntegerType})
+	if err == nil {
+		t.Fatal("expected ChangeColsIntegralityByRange to reject a mismatched range")
+	}
+	checkErr(t, raw.ChangeColsIntegralityByRange(1, 1, []VariableType{IntegerType}))
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{4.0, 5.0})
+}
+
+// TestFixIntegersAndExtract tests that fixing the integer columns of the
+// TestFullAPIMaxMIP model at their optimal values produces an LP with the
+// same objective value for which HiGHS returns dual values.
+func TestFixIntegersAndExtract(t *testing.T) {
+	// Solve the MIP.
+	model := minimalAPIModel(true)
+	model.VarTypes = []VariableType{IntegerType, IntegerType}
+	raw := mustToRawModel(t, model)
+	mipSoln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mipSoln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", mipSoln.Status)
+	}
+
+	// Fix the integers and solve the resulting LP.
+	lp, err := raw.FixIntegersAndExtract(mipSoln)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColLower", lp.ColLower, []float64{4.0, 5.0})
+	compSlices(t, "ColUpper", lp.ColUpper, []float64{4.0, 5.0})
+	lpSoln := solveRaw(t, lp)
+	if lpSoln.RowDual == nil || lpSoln.ColumnDual == nil {
+		t.Fatal("the fixed LP did not produce dual values")
+	}
+	if math.Abs(lpSoln.Objective-mipSoln.Objective) > 1e-6 {
+		t.Fatalf("expected an objective value of %v but saw %v",
+			mipSoln.Objective, lpSoln.Objective)
+	}
+}
+
+// TestLastWarning tests that LastWarning retrieves a warning that the caller
+// chose to tolerate.  HiGHS warns when a column's bounds are inconsistent.
+func TestLastWarning(t *testing.T) {
+	raw := NewRawModel()
+	if w := raw.LastWarning(); w != nil {
+		t.Fatalf("expected no warning but saw %v", w)
+	}
+	err := raw.AddColumnBounds([]float64{1.0}, []float64{0.0})
+	checkErr(t, err)
+	w := raw.LastWarning()
+	if w == nil {
+		t.Fatal("expected a warning but saw none")
+	}
+	if !w.IsWarning() || w.CName != "Highs_addVars" {
+		t.Fatalf("unexpected warning %#v", *w)
+	}
+
+	// Successful operations do not clear the warning.
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+	if raw.LastWarning() != w {
+		t.Fatal("LastWarning changed after a successful operation")
+	}
+}
+
+// TestSaveState tests that SaveState and LoadState carry a knapsack problem,
+// its options, and its first incumbent over to a fresh model, which then
+// solves to optimality.
+func TestSaveState(t *testing.T) {
+	// Stop the solve at the first incumbent.
+	raw := mustToRawModel(t, knapsackModel())
+	checkErr(t, raw.SetStringOption("presolve", "off"))
+	checkErr(t, raw.SetIntOption("mip_max_improving_sols", 1))
+	first, err := raw.Solve()
+	checkErr(t, err)
+
+	// Save the state and restore it into a new model.
+	var buf bytes.Buffer
+	checkErr(t, raw.SaveState(&buf))
+	restored := NewRawModel()
+	checkErr(t, restored.LoadState(&buf))
+	presolve, err := restored.GetStringOption("presolve")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if presolve != "off" {
+		t.Fatalf("expected presolve to be \"off\" but saw %q", presolve)
+	}
+
+	// Continue solving to optimality.
+	checkErr(t, restored.SetIntOption("mip_max_improving_sols", 1000000))
+	soln, err := restored.Solve()
+	checkErr(t, err)
+	if soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+	}
+	if soln.Objective > first.Objective || math.Abs(soln.Objective+56.0) > 1e-6 {
+		t.Fatalf("expected an objective of -56 (first incumbent %v) but saw %v",
+			first.Objective, soln.Objective)
+	}
+}
+
+// TestOptionDescription tests that OptionDescription returns a description of
+// the time_limit option and rejects unknown options.
+func TestOptionDescription(t *testing.T) {
+	raw := NewRawModel()
+	desc, err := raw.OptionDescription("time_limit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(strings.ToLower(desc), "time") {
+		t.Fatalf("unexpected description of time_limit: %q", desc)
+	}
+	_, err = raw.OptionDescription("no_such_option")
+	if err == nil {
+		t.Fatal("expected OptionDescription to reject an unknown option")
+	}
+}
+
+// TestOptionType tests that OptionType and OptionBounds report an option's
+// type and range.
+func TestOptionType(t *testing.T) {
+	raw := NewRawModel()
+	for opt, want := range map[string]OptionType{
+		"time_limit":  Float64Option,
+		"threads":     IntOption,
+		"output_flag": BoolOption,
+		"solver":      StringOption,
+	} {
+		oType, err := raw.OptionType(opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if oType != want {
+			t.Fatalf("expected %s to have type %v but saw %v", opt, want, oType)
+		}
+	}
+	min, max, def, err := raw.OptionBounds("time_limit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if min != 0.0 || !math.IsInf(max, 1) || !math.IsInf(def, 1) {
+		t.Fatalf("unexpected time_limit bounds [%v, %v] with default %v", min, max, def)
+	}
+	if _, _, _, err = raw.OptionBounds("solver"); err == nil {
+		t.Fatal("expected OptionBounds to reject a string option")
+	}
+	if _, err = raw.OptionType("no_such_option"); err == nil {
+		t.Fatal("expected OptionType to reject an unknown option")
+	}
+}
+
+// TestSolveScenarios tests that SolveScenarios solves the TestMinimalAPIMin
+// model under two sets of row bounds and restores the original bounds.
+func TestSolveScenarios(t *testing.T) {
+	raw := mustToRawModel(t, minimalAPIModel(false))
+	solns, err := raw.SolveScenarios(
+		[][]float64{{-1.0e30, 5.0, 6.0}, {-1.0e30, 7.0, 6.0}},
+		[][]float64{{7.0, 15.0, 1.0e30}, {7.0, 15.0, 1.0e30}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(solns) != 2 {
+		t.Fatalf("expected 2 solutions but saw %d", len(solns))
+	}
+	expected := []struct {
+		primal    []float64
+		objective float64
+	}{
+		{[]float64{0.5, 2.25}, 5.75},
+		{[]float64{0.0, 3.5}, 6.5},
+	}
+	for i, soln := range solns {
+		if soln.Status != Optimal {
+			t.Fatalf("scenario %d: Solve returned %s instead of Optimal", i, soln.Status)
+		}
+		compSlices(t, "ColumnPrimal", roundFloats(1e-6, soln.ColumnPrimal), expected[i].primal)
+		if math.Abs(soln.Objective-expected[i].objective) > 1e-6 {
+			t.Fatalf("scenario %d: objective value was %v but should have been %v",
+				i, soln.Objective, expected[i].objective)
+		}
+	}
+
+	// The original bounds should have been restored.
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(soln.Objective-5.75) > 1e-6 {
+		t.Fatalf("objective value was %v but should have been 5.75", soln.Objective)
+	}
+}
+
+// TestRawSolveBasis tests that RawModel.Solve populates the basis of a model
+// constructed with ToRawModel and leaves it empty, without error, when the
+// solver produces no basis.
+func TestRawSolveBasis(t *testing.T) {
+	raw := mustToRawModel(t, minimalAPIModel(false))
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(soln.ColumnBasis) != 2 || len(soln.RowBasis) != 3 {
+		t.Fatalf("expected 2 column and 3 row basis statuses but saw %v and %v",
+			soln.ColumnBasis, soln.RowBasis)
+	}
+
+	// Interior-point solves without crossover produce no basis.
+	raw = mustToRawModel(t, minimalAPIModel(false))
+	checkErr(t, raw.SetStringOption("solver", "ipm"))
+	checkErr(t, raw.SetStringOption("run_crossover", "off"))
+	soln, err = raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.ColumnBasis != nil || soln.RowBasis != nil {
+		t.Fatalf("expected no basis but saw %v and %v", soln.ColumnBasis, soln.RowBasis)
+	}
+}
+
+// TestDiagnoseInfeasibility tests that DiagnoseInfeasibility identifies the
+// rows responsible for a model's infeasibility and leaves the model intact.
+func TestDiagnoseInfeasibility(t *testing.T) {
+	// Prepare an infeasible model in which row 3 is irrelevant.
+	var model Model
+	model.ColLower = []float64{0.0, 0.0}
+	model.AddDenseRow(4.0, []float64{1.0, 1.0}, math.Inf(1))
+	model.AddDenseRow(math.Inf(-1), []float64{1.0, 0.0}, 1.0)
+	model.AddDenseRow(math.Inf(-1), []float64{0.0, 1.0}, 1.0)
+	model.AddDenseRow(math.Inf(-1), []float64{1.0, -1.0}, 10.0)
+	raw := mustToRawModel(t, &model)
+
+	// Diagnose the infeasibility.
+	rpt, err := raw.DiagnoseInfeasibility()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "IIS", rpt.IIS, []int{0, 1, 2})
+	expl := strings.Join(rpt.Explanations, "; ")
+	if expl != "row 0: c0 + c1 >= 4; row 1: c0 <= 1; row 2: c1 <= 1" {
+		t.Fatalf("unexpected explanations %q", expl)
+	}
+	if rpt.DualRay != nil {
+		compSlices(t, "RayRows", rpt.RayRows, []int{0, 1, 2})
+	}
+
+	// Ensure that the row bounds were restored.
+	lower, upper, err := raw.rowBounds()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "lower", lower, []float64{4.0, math.Inf(-1), math.Inf(-1), math.Inf(-1)})
+	compSlices(t, "upper", upper, []float64{math.Inf(1), 1.0, 1.0, 10.0})
+}
+
+// TestAddColumn tests that adding a cheaper column to a solved model improves
+// the objective value and that malformed columns are rejected.
+func TestAddColumn(t *testing.T) {
+	// Prepare and solve the model min 3*x_0 s.t. x_0 >= 2.
+	model := NewRawModel()
+	checkErr(t, model.SetBoolOption("output_flag", false))
+	checkErr(t, model.AddColumnBounds([]float64{0.0}, nil))
+	checkErr(t, model.SetColumnCosts([]float64{3.0}))
+	checkErr(t, model.AddDenseRow(2.0, []float64{1.0}, math.Inf(1)))
+	soln, err := model.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Objective != 6.0 {
+		t.Fatalf("objective value was %v but should have been 6", soln.Objective)
+	}
+
+	// Add a cheaper column to the row and re-solve.
+	checkErr(t, model.AddColumn(1.0, 0.0, math.Inf(1), []int{0}, []float64{1.0}))
+	soln, err = model.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{0.0, 2.0})
+	if soln.Objective != 2.0 {
+		t.Fatalf("objective value was %v but should have been 2", soln.Objective)
+	}
+
+	// Malformed columns are rejected.
+	if model.AddColumn(1.0, 0.0, 1.0, []int{0}, nil) == nil {
+		t.Fatal("expected AddColumn to reject mismatched slice lengths")
+	}
+	if model.AddColumn(1.0, 0.0, 1.0, []int{1}, []float64{1.0}) == nil {
+		t.Fatal("expected AddColumn to reject a nonexistent row")
+	}
+}
+
+// TestAddRow tests that adding a row that cuts off the optimum of the
+// TestFullAPIMin model changes the objective value and that malformed rows
+// are rejected.
+func TestAddRow(t *testing.T) {
+	// Solve the original model.
+	raw := mustToRawModel(t, minimalAPIModel(false))
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Objective != 5.75 {
+		t.Fatalf("objective value was %v but should have been 5.75", soln.Objective)
+	}
+
+	// Add the row x_0 >= 2 and re-solve.
+	checkErr(t, raw.AddRow(2.0, math.Inf(1), []int{0}, []float64{1.0}))
+	soln, err = raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{2.0, 1.5})
+	if soln.Objective != 6.5 {
+		t.Fatalf("objective value was %v but should have been 6.5", soln.Objective)
+	}
+
+	// Malformed rows are rejected.
+	if raw.AddRow(0.0, 1.0, []int{0, 1}, []float64{1.0}) == nil {
+		t.Fatal("expected AddRow to reject mismatched slice lengths")
+	}
+	if raw.AddRow(0.0, 1.0, []int{2}, []float64{1.0}) == nil {
+		t.Fatal("expected AddRow to reject a nonexistent column")
+	}
+}
+
+// TestSolveSense tests that SolveSense can find both the minimum and maximum
+// value of x_1 over the feasible region of the TestFullAPIMin model without
+// altering the model's objective sense.
+func TestSolveSense(t *testing.T) {
+	model := minimalAPIModel(false)
+	model.ColCosts = []float64{0.0, 1.0}
+	model.Offset = 0.0
+	raw := mustToRawModel(t, model)
+	for _, ex := range []struct {
+		sense ObjSense
+		obj   float64
+	}{
+		{Minimize, 1.0},
+		{Maximize, 7.0},
+	} {
+		soln, err := raw.SolveSense(ex.sense)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if soln.Objective != ex.obj {
+			t.Fatalf("%v: expected an objective value of %v but saw %v",
+				ex.sense, ex.obj, soln.Objective)
+		}
+		max, err := raw.isMaximization()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if max {
+			t.Fatalf("%v: SolveSense did not restore the objective sense", ex.sense)
+		}
+	}
+}
+
+// TestChangeColumnCosts tests that changing the costs of the TestFullAPIMin
+// model in place moves the optimum as expected.
+func TestChangeColumnCosts(t *testing.T) {
+	// Solve the original model.
+	raw := mustToRawModel(t, minimalAPIModel(false))
+	_, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Change a single cost and re-solve.
+	checkErr(t, raw.ChangeColumnCost(0, 3.0))
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{0.0, 3.0})
+	if soln.Objective != 6.0 {
+		t.Fatalf("objective value was %v but should have been 6", soln.Objective)
+	}
+
+	// Change both costs and re-solve.
+	checkErr(t, raw.ChangeColumnCosts([]int{0, 1}, []float64{1.0, 3.0}))
+	soln, err = raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{3.0, 1.0})
+	if soln.Objective != 9.0 {
+		t.Fatalf("objective value was %v but should have been 9", soln.Objective)
+	}
+
+	// Out-of-range columns are rejected.
+	if raw.ChangeColumnCost(2, 1.0) == nil {
+		t.Fatal("expected ChangeColumnCost to reject a nonexistent column")
+	}
+	if raw.ChangeColumnCosts([]int{-1}, []float64{1.0}) == nil {
+		t.Fatal("expected ChangeColumnCosts to reject a nonexistent column")
+	}
+}
+
+// TestNonDefaultOptions tests that NonDefaultOptions reports exactly the
+// options that were changed from their defaults.
+func TestNonDefaultOptions(t *testing.T) {
+	model := NewRawModel()
+	checkErr(t, model.SetBoolOption("output_flag", true)) // Undo DefaultSilent.
+	checkErr(t, model.SetStringOption("presolve", "off"))
+	checkErr(t, model.SetFloat64Option("time_limit", 100.0))
+	opts, err := model.NonDefaultOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(opts) != 2 || opts["presolve"] != "off" || opts["time_limit"] != 100.0 {
+		t.Fatalf("unexpected non-default options %v", opts)
+	}
+}
+
+// TestChangeColumnBounds tests that fixing columns of the TestFullAPIMin model
+// by setting equal bounds is respected by the solver and that invalid bounds
+// are rejected.
+func TestChangeColumnBounds(t *testing.T) {
+	// Fix x_0 at 2 and solve.
+	raw := mustToRawModel(t, minimalAPIModel(false))
+	checkErr(t, raw.ChangeColumnBounds(0, 2.0, 2.0))
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{2.0, 1.5})
+
+	// Fix both columns and re-solve.
+	checkErr(t, raw.ChangeColumnsBounds([]int{0, 1}, []float64{1.0, 3.0}, []float64{1.0, 3.0}))
+	soln, err = raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{1.0, 3.0})
+
+	// Invalid bounds are rejected, but infinite bounds are not checked.
+	if raw.ChangeColumnBounds(0, 3.0, 2.0) == nil {
+		t.Fatal("expected ChangeColumnBounds to reject crossed bounds")
+	}
+	if raw.ChangeColumnBounds(2, 0.0, 1.0) == nil {
+		t.Fatal("expected ChangeColumnBounds to reject a nonexistent column")
+	}
+	checkErr(t, raw.ChangeColumnsBounds([]int{0}, []float64{0.0}, []float64{math.Inf(1)}))
+	if raw.ChangeColumnBounds(0, math.Inf(1), math.Inf(1)) == nil {
+		t.Fatal("expected ChangeColumnBounds to reject a lower bound of +Inf")
+	}
+	if raw.ChangeColumnBounds(0, math.Inf(-1), math.Inf(-1)) == nil {
+		t.Fatal("expected ChangeColumnBounds to reject an upper bound of -Inf")
+	}
+}
+
+// TestChangeRowBounds tests that widening a binding row of the TestFullAPIMin
+// model improves the objective value and that nonexistent rows are rejected.
+func TestChangeRowBounds(t *testing.T) {
+	// Solve the original model.
+	raw := mustToRawModel(t, minimalAPIModel(false))
+	_, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Relax 5 <= x_0 + 2x_1 <= 15 to 4 <= x_0 + 2x_1 <= 15 and re-solve.
+	checkErr(t, raw.ChangeRowBounds(1, 4.0, 15.0))
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{1.0, 1.5})
+	if soln.Objective != 5.5 {
+		t.Fatalf("objective value was %v but should have been 5.5", soln.Objective)
+	}
+
+	// Nonexistent rows are rejected.
+	if raw.ChangeRowBounds(3, 0.0, 1.0) == nil {
+		t.Fatal("expected ChangeRowBounds to reject a nonexistent row")
+	}
+}
+
+// TestChangeCoefficient tests that changing a matrix coefficient of the
+// TestFullAPIMin model changes both the row activities and the objective
+// value.
+func TestChangeCoefficient(t *testing.T) {
+	// Replace 6 <= 3x_0 + 2x_1 with 6 <= x_0 + 2x_1 and solve.
+	raw := mustToRawModel(t, minimalAPIModel(false))
+	checkErr(t, raw.ChangeCoefficient(2, 0, 1.0))
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{0.0, 3.0})
+	compSlices(t, "RowPrimal", soln.RowPrimal, []float64{3.0, 6.0, 6.0})
+	if soln.Objective != 6.0 {
+		t.Fatalf("objective value was %v but should have been 6", soln.Objective)
+	}
+
+	// Out-of-range coefficients are rejected.
+	if raw.ChangeCoefficient(3, 0, 1.0) == nil {
+		t.Fatal("expected ChangeCoefficient to reject a nonexistent row")
+	}
+	if raw.ChangeCoefficient(0, 2, 1.0) == nil {
+		t.Fatal("expected ChangeCoefficient to reject a nonexistent column")
+	}
+}
+
+// TestDeleteColumns tests that deleting the middle column of a three-column
+// model leaves a model whose remaining columns solve correctly.
+func TestDeleteColumns(t *testing.T) {
+	// Solve min x_0 + 0.5x_1 + 2x_2 s.t. 2 <= x_0 + x_1 + x_2 with all
+	// columns in [0, 1].
+	var model Model
+	model.ColCosts = []float64{1.0, 0.5, 2.0}
+	model.ColLower = []float64{0.0, 0.0, 0.0}
+	model.ColUpper = []float64{1.0, 1.0, 1.0}
+	model.AddDenseRow(2.0, []float64{1.0, 1.0, 1.0}, math.Inf(1))
+	raw := mustToRawModel(t, &model)
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{1.0, 1.0, 0.0})
+
+	// Delete x_1 and re-solve.
+	checkErr(t, raw.DeleteColumns(1, 1))
+	soln, err = raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{1.0, 1.0})
+	if soln.Objective != 3.0 {
+		t.Fatalf("objective value was %v but should have been 3", soln.Objective)
+	}
+
+	// Invalid ranges are rejected.
+	if raw.DeleteColumns(1, 2) == nil {
+		t.Fatal("expected DeleteColumns to reject a nonexistent column")
+	}
+}
+
+// TestDeleteRows tests that deleting the non-binding first row of the
+// TestFullAPIMin model leaves the optimum unchanged and shrinks the solution.
+func TestDeleteRows(t *testing.T) {
+	raw := mustToRawModel(t, minimalAPIModel(false))
+	checkErr(t, raw.DeleteRows(0, 0))
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{0.5, 2.25})
+	compSlices(t, "RowPrimal", soln.RowPrimal, []float64{5.0, 6.0})
+	compSlices(t, "RowBasis", soln.RowBasis, []BasisStatus{Lower, Lower})
+	if soln.Objective != 5.75 {
+		t.Fatalf("objective value was %v but should have been 5.75", soln.Objective)
+	}
+
+	// Invalid ranges are rejected.
+	if raw.DeleteRows(1, 0) == nil {
+		t.Fatal("expected DeleteRows to reject an empty range")
+	}
+}
+
+// TestModelDimensions tests that NumCols, NumRows, and NumNonzeros report the
+// dimensions of the TestFullAPIMin model.
+func TestModelDimensions(t *testing.T) {
+	raw := mustToRawModel(t, minimalAPIModel(false))
+	if nc, nr, nnz := raw.NumCols(), raw.NumRows(), raw.NumNonzeros(); nc != 2 || nr != 3 || nnz != 5 {
+		t.Fatalf("expected 2 columns, 3 rows, and 5 nonzeros but saw %d, %d, and %d",
+			nc, nr, nnz)
+	}
+}
+
+// TestInfinity tests that Infinity returns a very large value and that a
+// bound set to that value is treated as absent.
+func TestInfinity(t *testing.T) {
+	inf := Infinity()
+	if inf < 1e20 {
+		t.Fatalf("expected Infinity to be very large but saw %v", inf)
+	}
+
+	// Maximize x_0 subject to 0 <= x_0 <= Infinity().
+	model := NewRawModel()
+	checkErr(t, model.SetBoolOption("output_flag", false))
+	checkErr(t, model.SetMaximization(true))
+	checkErr(t, model.AddColumnBounds([]float64{0.0}, []float64{inf}))
+	checkErr(t, model.SetColumnCosts([]float64{1.0}))
+	soln, err := model.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status != Unbounded && soln.Status != UnboundedOrInfeasible {
+		t.Fatalf("Solve returned %s instead of Unbounded", soln.Status)
+	}
+}
+
+// TestSetBasis tests that a basis retrieved with GetBasis warm-starts a
+// solve of a slightly perturbed model.
+func TestSetBasis(t *testing.T) {
+	// Solve the original model and capture its basis.
+	model := minimalAPIModel(false)
+	raw := mustToRawModel(t, model)
+	checkErr(t, raw.SetStringOption("presolve", "off"))
+	_, err := raw.Solve()
+	checkErr(t, err)
+	colBasis, rowBasis, err := raw.GetBasis()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(colBasis) != 2 || len(rowBasis) != 3 {
+		t.Fatalf("expected 2 column and 3 row statuses but saw %v and %v", colBasis, rowBasis)
+	}
+
+	// Perturb a cost, and solve the result both cold and warm.  The
+	// original basis remains optimal.
+	model.ColCosts[0] = 1.1
+	iters := make([]int, 2)
+	for i, warm := range []bool{false, true} {
+		raw = mustToRawModel(t, model)
+		checkErr(t, raw.SetStringOption("presolve", "off"))
+		if warm {
+			checkErr(t, raw.SetBasis(colBasis, rowBasis))
+		}
+		soln, err := raw.Solve()
+		checkErr(t, err)
+		compSlices(t, "ColumnPrimal", roundFloats(1e-6, soln.ColumnPrimal), []float64{0.5, 2.25})
+		iters[i], err = soln.GetIntInfo("simplex_iteration_count")
+		checkErr(t, err)
+	}
+	if iters[1] >= iters[0] {
+		t.Fatalf("expected fewer than %d iterations with a warm start but saw %d", iters[0], iters[1])
+	}
+
+	// Bases of the wrong length are rejected.
+	if raw.SetBasis(colBasis[:1], rowBasis) == nil {
+		t.Fatal("expected SetBasis to reject a short column basis")
+	}
+}
+
+// TestGetObjectiveCoefficients tests that column costs can be read back from
+// a raw model.
+func TestGetObjectiveCoefficients(t *testing.T) {
+	raw := mustToRawModel(t, minimalAPIModel(false))
+	cost, err := raw.GetObjectiveCoefficients()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "cost", cost, []float64{1.0, 1.0})
+	checkErr(t, raw.ChangeColumnCosts([]int{0, 1}, []float64{2.5, -4.0}))
+	cost, err = raw.GetObjectiveCoefficients()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "cost", cost, []float64{2.5, -4.0})
+}
+
+// convertStatusCode applies convertHighsModelStatus to an integer status
+// code.  It exists because test files cannot refer to C types.
+func convertStatusCode[T ~int32 | ~int64](conv func(T) ModelStatus, code int) ModelStatus {
+	return conv(T(code))
+}
+
+// TestConvertHighsModelStatus tests that each HiGHS model status, identified
+// by the value HiGHS assigns to its kHighsModelStatus constant, maps to the
+// corresponding ModelStatus and that each has a distinct name.
+func TestConvertHighsModelStatus(t *testing.T) {
+	for code, want := range []ModelStatus{
+		NotSet,                // kHighsModelStatusNotset
+		LoadError,             // kHighsModelStatusLoadError
+		ModelError,            // kHighsModelStatusModelError
+		PresolveError,         // kHighsModelStatusPresolveError
+		SolveError,            // kHighsModelStatusSolveError
+		PostsolveError,        // kHighsModelStatusPostsolveError
+		ModelEmpty,            // kHighsModelStatusModelEmpty
+		Optimal,               // kHighsModelStatusOptimal
+		Infeasible,            // kHighsModelStatusInfeasible
+		UnboundedOrInfeasible, // kHighsModelStatusUnboundedOrInfeasible
+		Unbounded,             // kHighsModelStatusUnbounded
+		ObjectiveBound,        // kHighsModelStatusObjectiveBound
+		ObjectiveTarget,       // kHighsModelStatusObjectiveTarget
+		TimeLimit,             // kHighsModelStatusTimeLimit
+		IterationLimit,        // kHighsModelStatusIterationLimit
+		Unknown,               // kHighsModelStatusUnknown
+		SolutionLimit,         // kHighsModelStatusSolutionLimit
+		Interrupt,             // kHighsModelStatusInterrupt
+	} {
+		ms := convertStatusCode(convertHighsModelStatus, code)
+		if ms != want {
+			t.Fatalf("expected HiGHS status %d to map to %v but saw %v", code, want, ms)
+		}
+	}
+
+	// Confirm that each ModelStatus has a distinct name.
+	seen := make(map[string]bool)
+	for ms := UnknownModelStatus; ms <= Interrupt; ms++ {
+		str := ms.String()
+		if str == "" || strings.HasPrefix(str, "ModelStatus(") {
+			t.Fatalf("ModelStatus %d has no name", int(ms))
+		}
+		if seen[str] {
+			t.Fatalf("more than one status is named %q", str)
+		}
+		seen[str] = true
+	}
+
+	// Confirm that an unrecognized status maps to UnknownModelStatus.
+	if ms := convertStatusCode(convertHighsModelStatus, 1000); ms != UnknownModelStatus {
+		t.Fatalf("expected an unrecognized status to map to %v but saw %v",
+			UnknownModelStatus, ms)
+	}
+}
+
+// TestOptionCallStatus tests that option setters and getters report failures
+// as CallStatus values, which lets callers distinguish warnings from errors.
+// HiGHS rejects, rather than clamps, out-of-range option values.
+func TestOptionCallStatus(t *testing.T) {
+	raw := NewRawModel()
+	for _, tc := range []struct {
+		err    error
+		cName  string
+		goName string
+	}{
+		{raw.SetFloat64Option("time_limit", -1.0), "Highs_setDoubleOptionValue", "SetFloat64Option"},
+		{raw.SetIntOption("no_such_option", 1), "Highs_setIntOptionValue", "SetIntOption"},
+		{raw.SetStringOption("presolve", "sometimes"), "Highs_setStringOptionValue", "SetStringOption"},
+	} {
+		var cs CallStatus
+		if !errors.As(tc.err, &cs) {
+			t.Fatalf("expected a CallStatus but saw %v", tc.err)
+		}
+		if cs.IsWarning() || cs.CName != tc.cName || cs.GoName != tc.goName {
+			t.Fatalf("unexpected status %#v", cs)
+		}
+	}
+	_, err := raw.GetBoolOption("no_such_option")
+	var cs CallStatus
+	if !errors.As(err, &cs) || cs.IsWarning() {
+		t.Fatalf("expected an error CallStatus but saw %v", err)
+	}
+}
@@ -4,6 +4,8 @@
 package highs
 
 import (
+	"errors"
+	"math"
 	"testing"
 )
 
@@ -197,3 +199,574 @@ func TestLPModelToRawModel(t *testing.T) {
 	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{3.0, 2.0})
 	compSlices(t, "RowPrimal", soln.RowPrimal, []float64{1.0, 5.0})
 }
+
+// TestScaling tests that a model with user-specified column and row scale
+// factors yields the same solution as the unscaled TestMinimalAPIMin model.
+func TestScaling(t *testing.T) {
+	// Prepare the model.
+	model := minimalAPIModel(false)
+	model.ColScale = []float64{2.0, 0.5}
+	model.RowScale = []float64{0.0, 10.0, 0.1}
+
+	// Solve the model.
+	soln, err := model.Solve()
+	if err != nil {
+		t.Fatalf("Solve failed (%s)", err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+	}
+
+	// Confirm that the solution is expressed in the original units.
+	compSlices(t, "ColumnPrimal", roundFloats(1e-6, soln.ColumnPrimal), []float64{0.5, 2.25})
+	compSlices(t, "RowPrimal", roundFloats(1e-6, soln.RowPrimal), []float64{2.25, 5.0, 6.0})
+	compSlices(t, "RowDual", roundFloats(1e-6, soln.RowDual), []float64{0.0, 0.25, 0.25})
+	if math.Abs(soln.Objective-5.75) > 1e-6 {
+		t.Fatalf("objective value was %.2f but should have been 5.75", soln.Objective)
+	}
+}
+
+// TestDual tests that the primal and dual forms of the minimization and
+// maximization variants of the minimal API model have equal objective values.
+func TestDual(t *testing.T) {
+	for _, maximize := range []bool{false, true} {
+		primal := minimalAPIModel(maximize)
+		dual, err := primal.Dual()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dual.Maximize == maximize {
+			t.Fatal("the dual should have the opposite objective sense from the primal")
+		}
+		pSoln, err := primal.Solve()
+		if err != nil {
+			t.Fatal(err)
+		}
+		dSoln, err := dual.Solve()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dSoln.Status != Optimal {
+			t.Fatalf("Solve returned %s instead of Optimal", dSoln.Status)
+		}
+		if math.Abs(pSoln.Objective-dSoln.Objective) > 1e-6 {
+			t.Fatalf("primal objective (%v) differs from dual objective (%v)",
+				pSoln.Objective, dSoln.Objective)
+		}
+	}
+}
+
+// TestRedundantRows tests that RedundantRows flags an obviously redundant row
+// added to the TestMinimalAPIMin model, and only that row.
+func TestRedundantRows(t *testing.T) {
+	model := minimalAPIModel(false)
+	model.AddDenseRow(math.Inf(-1), []float64{1.0, 1.0}, 100.0)
+	rows, err := model.RedundantRows(1e-9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "RedundantRows", rows, []int{3})
+
+	// Of two identical binding rows, only one can be removed.
+	model = minimalAPIModel(false)
+	model.AddDenseRow(math.Inf(-1), []float64{1.0, 1.0}, 9.0)
+	model.AddDenseRow(math.Inf(-1), []float64{1.0, 1.0}, 9.0)
+	rows, err = model.RedundantRows(1e-9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "RedundantRows", rows, []int{3})
+}
+
+// TestIterationLimit tests that a tiny IterationLimit stops the simplex
+// solver early with an IterationLimit status and a warning.
+func TestIterationLimit(t *testing.T) {
+	// Construct an LP that requires several simplex iterations:
+	// maximize the sum of x subject to a set of dense ≤ constraints.
+	const n = 10
+	var model Model
+	model.Maximize = true
+	model.ColLower = make([]float64, n)
+	for r := 0; r < n; r++ {
+		coeffs := make([]float64, n)
+		for c := range coeffs {
+			coeffs[c] = float64(1 + (r*c+r+c)%7)
+		}
+		model.AddDenseRow(math.Inf(-1), coeffs, float64(10+r))
+	}
+	model.IterationLimit = 1
+	raw := mustToRawModel(t, &model)
+	lim, err := raw.GetIntOption("simplex_iteration_limit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lim != 1 {
+		t.Fatalf("expected a simplex iteration limit of 1 but saw %d", lim)
+	}
+
+	// Solve without presolve, which might otherwise solve the model
+	// outright.
+	checkErr(t, raw.SetStringOption("presolve", "off"))
+	checkErr(t, raw.SetStringOption("solver", "simplex"))
+	soln, err := raw.Solve()
+	var cs CallStatus
+	if err != nil && !(errors.As(err, &cs) && cs.IsWarning()) {
+		t.Fatal(err)
+	}
+	if soln.Status != IterationLimit {
+		t.Fatalf("expected status IterationLimit but saw %v", soln.Status)
+	}
+	if len(soln.ColumnPrimal) != n {
+		t.Fatalf("expected %d primal values but saw %d", n, len(soln.ColumnPrimal))
+	}
+
+	// Ensure that a negative iteration limit is rejected.
+	model.IterationLimit = -1
+	_, err = model.ToRawModel()
+	if err == nil {
+		t.Fatal("expected ToRawModel to reject a negative IterationLimit")
+	}
+}
+
+// denseMatrix is a minimal row-major matrix that satisfies the Matrix
+// interface in the same way as gonum's mat.Dense.
+type denseMatrix struct {
+	rows, cols int
+	data       []float64
+}
+
+func (d denseMatrix) Dims() (int, int)    { return d.rows, d.cols }
+func (d denseMatrix) At(i, j int) float64 { return d.data[i*d.cols+j] }
+
+// TestNewModelFromMatrix tests that NewModelFromMatrix reproduces the
+// TestMinimalAPIMin model from a dense constraint matrix.
+func TestNewModelFromMatrix(t *testing.T) {
+	a := denseMatrix{rows: 3, cols: 2, data: []float64{
+		0.0, 1.0,
+		1.0, 2.0,
+		3.0, 2.0,
+	}}
+	model := NewModelFromMatrix([]float64{1.0, 1.0}, a,
+		[]float64{-1.0e30, 5.0, 6.0}, []float64{7.0, 15.0, 1.0e30},
+		[]float64{0.0, 1.0}, []float64{4.0, 1.0e30})
+	model.Offset = 3.0
+	soln := solveRaw(t, model)
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{0.5, 2.25})
+	if soln.Objective != 5.75 {
+		t.Fatalf("objective value was %v but should have been 5.75", soln.Objective)
+	}
+}
+
+// TestSolveLP tests that SolveLP solves the TestMinimalAPIMin model, less
+// its objective offset, in a single call.
+func TestSolveLP(t *testing.T) {
+	soln, err := SolveLP([]float64{1.0, 1.0},
+		[][]float64{
+			{0.0, 1.0},
+			{1.0, 2.0},
+			{3.0, 2.0},
+		},
+		[]float64{-1.0e30, 5.0, 6.0}, []float64{7.0, 15.0, 1.0e30},
+		[]float64{0.0, 1.0}, []float64{4.0, 1.0e30},
+		false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("SolveLP returned %s instead of Optimal", soln.Status)
+	}
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{0.5, 2.25})
+	if soln.Objective != 2.75 {
+		t.Fatalf("objective value was %v but should have been 2.75", soln.Objective)
+	}
+}
+
+// TestSolveTimed tests that SolveTimed populates each timing field and that
+// the phases sum to no more than the total.
+func TestSolveTimed(t *testing.T) {
+	soln, timing, err := minimalAPIModel(false).SolveTimed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("SolveTimed returned %s instead of Optimal", soln.Status)
+	}
+	t.Logf("Timing: %+v", timing)
+	if timing.Build <= 0 || timing.Run <= 0 || timing.Extract <= 0 {
+		t.Fatalf("timing fields were not all populated: %+v", timing)
+	}
+	if timing.Build+timing.Run+timing.Extract > timing.Total {
+		t.Fatalf("phases exceed the total time: %+v", timing)
+	}
+	if timing.HiGHS < 0 || timing.HiGHS > timing.Total {
+		t.Fatalf("HiGHS's run time is implausible: %+v", timing)
+	}
+
+	// Ensure that a scaled model's solution is expressed in the original
+	// units, as in TestScaling.
+	model := minimalAPIModel(false)
+	model.ColScale = []float64{2.0, 0.5}
+	model.RowScale = []float64{0.0, 10.0, 0.1}
+	soln, _, err = model.SolveTimed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", roundFloats(1e-6, soln.ColumnPrimal), []float64{0.5, 2.25})
+	compSlices(t, "RowPrimal", roundFloats(1e-6, soln.RowPrimal), []float64{2.25, 5.0, 6.0})
+}
+
+// TestNewMinCostFlowModel tests that NewMinCostFlowModel models a small
+// network whose minimum-cost flow sends two units along each of the paths
+// 0→2→3 and 0→1→2→3, for a total cost of 14.
+func TestNewMinCostFlowModel(t *testing.T) {
+	arcs := []Arc{
+		{From: 0, To: 1, Cost: 2.0, Capacity: 4.0},
+		{From: 0, To: 2, Cost: 2.0, Capacity: 2.0},
+		{From: 1, To: 2, Cost: 1.0, Capacity: 2.0},
+		{From: 1, To: 3, Cost: 3.0, Capacity: 3.0},
+		{From: 2, To: 3, Cost: 1.0, Capacity: math.Inf(1)},
+	}
+	supply := []float64{4.0, 0.0, 0.0, -4.0}
+	model, err := NewMinCostFlowModel(4, arcs, supply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !model.IsNetworkFlow() {
+		t.Fatal("NewMinCostFlowModel produced a model that is not a network")
+	}
+	soln := solveRaw(t, model)
+	compSlices(t, "ColumnPrimal", roundFloats(1e-6, soln.ColumnPrimal),
+		[]float64{2.0, 2.0, 2.0, 0.0, 4.0})
+	if math.Abs(soln.Objective-14.0) > 1e-6 {
+		t.Fatalf("objective value was %v but should have been 14", soln.Objective)
+	}
+
+	// The row bounds do not share storage with each other or with the
+	// caller's supplies.
+	model.RowLower[0] = 0.0
+	if model.RowUpper[0] != 4.0 || supply[0] != 4.0 {
+		t.Fatal("modifying RowLower modified RowUpper or the supplies")
+	}
+
+	// Unbalanced supplies are rejected.
+	_, err = NewMinCostFlowModel(4, arcs, []float64{4.0, 0.0, 0.0, -3.0})
+	if err == nil {
+		t.Fatal("expected NewMinCostFlowModel to reject unbalanced supplies")
+	}
+}
+
+// TestInfinityBound tests that raising InfinityBound lets HiGHS treat a bound
+// of 1e25 as finite.
+func TestInfinityBound(t *testing.T) {
+	// By default, a bound of 1e25 is infinite, so the model is unbounded.
+	var model Model
+	model.ColCosts = []float64{-1.0}
+	model.ColLower = []float64{0.0}
+	model.ColUpper = []float64{1e25}
+	model.AddDenseRow(0.0, []float64{1.0}, math.Inf(1))
+	raw := mustToRawModel(t, &model)
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status == Optimal {
+		t.Fatal("expected a bound of 1e25 to be treated as infinite")
+	}
+
+	// With a higher threshold, the bound is finite.
+	model.InfinityBound = 1e30
+	soln = solveRaw(t, &model)
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{1e25})
+
+	// Negative thresholds are rejected.
+	model.InfinityBound = -1.0
+	_, err = model.ToRawModel()
+	if err == nil {
+		t.Fatal("expected ToRawModel to reject a negative InfinityBound")
+	}
+}
+
+// TestPresolveReductionLimit tests that a model's PresolveReductionLimit,
+// including a limit of zero, is passed to HiGHS, that a limited presolve
+// still leads to an optimal solution, and that negative limits are rejected.
+func TestPresolveReductionLimit(t *testing.T) {
+	model := minimalAPIModel(false)
+	for _, want := range []int{1, 0} {
+		limit := want
+		model.PresolveReductionLimit = &limit
+		raw := mustToRawModel(t, model)
+		lim, err := raw.GetIntOption("presolve_reduction_limit")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lim != want {
+			t.Fatalf("expected a presolve reduction limit of %d but saw %d", want, lim)
+		}
+		soln, err := raw.Solve()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if soln.Status != Optimal || soln.Objective != 5.75 {
+			t.Fatalf("expected an optimal objective of 5.75 but saw %v (%s)",
+				soln.Objective, soln.Status)
+		}
+	}
+
+	// Negative limits are rejected.
+	limit := -1
+	model.PresolveReductionLimit = &limit
+	_, err := model.ToRawModel()
+	if err == nil {
+		t.Fatal("expected ToRawModel to reject a negative PresolveReductionLimit")
+	}
+}
+
+// TestChebyshevCenter tests that ChebyshevCenter finds the incircle of the
+// triangle 0 <= x_0, 0 <= x_1, x_0 + x_1 <= 2.
+func TestChebyshevCenter(t *testing.T) {
+	var model Model
+	model.ColLower = []float64{0.0, 0.0}
+	model.AddDenseRow(math.Inf(-1), []float64{1.0, 1.0}, 2.0)
+	center, radius, err := model.ChebyshevCenter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := 2.0 / (2.0 + math.Sqrt2)
+	if math.Abs(radius-r) > 1e-6 {
+		t.Fatalf("expected a radius of %v but saw %v", r, radius)
+	}
+	compSlices(t, "center", roundFloats(1e-6, center), roundFloats(1e-6, []float64{r, r}))
+
+	// An unbounded region contains arbitrarily large balls.
+	model.RowUpper = nil
+	model.RowLower = nil
+	model.ConstMatrix = nil
+	_, _, err = model.ChebyshevCenter()
+	if err == nil {
+		t.Fatal("expected ChebyshevCenter to reject an unbounded region")
+	}
+}
+
+// TestBatchSolveMap tests that BatchSolveMap returns each model's result under
+// the model's own key.
+func TestBatchSolveMap(t *testing.T) {
+	bad := minimalAPIModel(false)
+	bad.ColUpper = []float64{1.0, 2.0, 3.0} // Inconsistent column counts
+	models := map[string]*Model{
+		"min": minimalAPIModel(false),
+		"max": minimalAPIModel(true),
+		"bad": bad,
+		"nil": nil,
+	}
+	results := BatchSolveMap(models, 2)
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results but saw %d", len(results))
+	}
+	for id, obj := range map[string]float64{"min": 5.75, "max": 12.5} {
+		res := results[id]
+		if res.Err != nil {
+			t.Fatalf("%s: %v", id, res.Err)
+		}
+		if res.Solution.Objective != obj {
+			t.Fatalf("%s: expected an objective value of %v but saw %v",
+				id, obj, res.Solution.Objective)
+		}
+	}
+	if results["bad"].Err == nil {
+		t.Fatal("expected an error for the malformed model")
+	}
+	if results["nil"].Err == nil {
+		t.Fatal("expected an error for the nil model")
+	}
+}
+
+// TestPresolveHints tests that PresolveMaxFillIn, including a maximum fill-in
+// of zero, is passed to HiGHS and that ImpliedFreeColumns is reported as
+// unsupported.
+func TestPresolveHints(t *testing.T) {
+	model := minimalAPIModel(false)
+	for _, want := range []int{5, 0} {
+		maxFill := want
+		model.PresolveMaxFillIn = &maxFill
+		raw := mustToRawModel(t, model)
+		fill, err := raw.GetIntOption("presolve_substitution_maxfillin")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fill != want {
+			t.Fatalf("expected a maximum fill-in of %d but saw %d", want, fill)
+		}
+	}
+
+	// Implied-free hints are not supported.
+	model.ImpliedFreeColumns = []int{1}
+	_, err := model.ToRawModel()
+	if !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported but received %v", err)
+	}
+}
+
+// TestAnalyticCenter tests that AnalyticCenter returns a strictly interior
+// point of the region 0 <= x_0 <= 2, 0 <= x_1 <= 2, x_0 + x_1 <= 3.
+func TestAnalyticCenter(t *testing.T) {
+	var model Model
+	model.ColCosts = []float64{1.0, -1.0} // Ignored
+	model.ColLower = []float64{0.0, 0.0}
+	model.ColUpper = []float64{2.0, 2.0}
+	model.AddDenseRow(math.Inf(-1), []float64{1.0, 1.0}, 3.0)
+	x, err := model.AnalyticCenter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	const margin = 1e-3
+	if len(x) != 2 || x[0] < margin || x[0] > 2.0-margin ||
+		x[1] < margin || x[1] > 2.0-margin || x[0]+x[1] > 3.0-margin {
+		t.Fatalf("%v is not strictly interior", x)
+	}
+
+	// Scale factors do not change the point returned.
+	model.ColScale = []float64{4.0, 0.5}
+	model.RowScale = []float64{10.0}
+	xs, err := model.AnalyticCenter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "AnalyticCenter", roundFloats(1e-6, xs), roundFloats(1e-6, x))
+}
+
+// TestSimplexDualEdgeWeight tests that a model's SimplexDualEdgeWeight is
+// passed to HiGHS, that different pricing strategies reach the same optimum,
+// and that invalid strategies are rejected.
+func TestSimplexDualEdgeWeight(t *testing.T) {
+	// Construct an LP that requires several simplex iterations.
+	const n = 10
+	var model Model
+	model.Maximize = true
+	model.ColLower = make([]float64, n)
+	for r := 0; r < n; r++ {
+		coeffs := make([]float64, n)
+		for c := range coeffs {
+			coeffs[c] = float64(1 + (r*c+r+c)%7)
+		}
+		model.AddDenseRow(math.Inf(-1), coeffs, float64(10+r))
+	}
+
+	// Solve the LP with each of two pricing strategies.
+	var objs []float64
+	for _, ew := range []SimplexDualEdgeWeight{SimplexDualEdgeWeightDantzig, SimplexDualEdgeWeightSteepestEdge} {
+		model.SimplexDualEdgeWeight = ew
+		raw := mustToRawModel(t, &model)
+		strat, err := raw.GetIntOption("simplex_dual_edge_weight_strategy")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strat != int(ew)-1 {
+			t.Fatalf("%v: expected a strategy of %d but saw %d", ew, int(ew)-1, strat)
+		}
+		checkErr(t, raw.SetStringOption("presolve", "off"))
+		checkErr(t, raw.SetStringOption("solver", "simplex"))
+		soln, err := raw.Solve()
+		if err != nil {
+			t.Fatal(err)
+		}
+		objs = append(objs, soln.Objective)
+	}
+	if math.Abs(objs[0]-objs[1]) > 1e-9 {
+		t.Fatalf("pricing strategies led to different optima: %v", objs)
+	}
+
+	// Ensure that an invalid strategy is rejected.
+	model.SimplexDualEdgeWeight = SimplexDualEdgeWeight(-1)
+	_, err := model.ToRawModel()
+	if err == nil {
+		t.Fatal("expected ToRawModel to reject a pricing strategy of -1")
+	}
+}
+
+// TestParametricObjective tests that sweeping from one objective to another
+// trades off the two monotonically.
+func TestParametricObjective(t *testing.T) {
+	model := minimalAPIModel(false)
+	c1 := []float64{1.0, 0.0}
+	c2 := []float64{0.0, 1.0}
+	lambdas := []float64{0.0, 0.1, 0.25, 0.5, 0.75, 0.9, 1.0}
+	solns, err := model.ParametricObjective(c1, c2, lambdas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(solns) != len(lambdas) {
+		t.Fatalf("expected %d solutions but saw %d", len(lambdas), len(solns))
+	}
+
+	// As λ increases, c1ᵀx should not decrease and c2ᵀx should not
+	// increase.
+	const tol = 1e-6
+	for i := 1; i < len(solns); i++ {
+		prev, cur := solns[i-1].ColumnPrimal, solns[i].ColumnPrimal
+		if cur[0] < prev[0]-tol || cur[1] > prev[1]+tol {
+			t.Fatalf("trade-off is not monotone between λ=%v (%v) and λ=%v (%v)",
+				lambdas[i-1], prev, lambdas[i], cur)
+		}
+	}
+
+	// Mismatched cost vectors are rejected.
+	if _, err := model.ParametricObjective(c1, c2[:1], lambdas); err == nil {
+		t.Fatal("expected ParametricObjective to reject a short cost vector")
+	}
+}
+
+// TestSolveExactCheck tests that SolveExactCheck confirms that the minimal
+// model's optimal basis is exactly optimal and that the underlying check
+// rejects a suboptimal basis.
+func TestSolveExactCheck(t *testing.T) {
+	// Check the optimal basis.  x1 ≤ 7 is slack, and the other two rows
+	// are binding at their lower bounds.
+	model := minimalAPIModel(false)
+	colBasis := []BasisStatus{Basic, Basic}
+	rowBasis := []BasisStatus{Basic, Lower, Lower}
+	exact, err := model.isExactlyOptimal(colBasis, rowBasis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exact {
+		t.Fatal("the optimal basis was not recognized as exactly optimal")
+	}
+
+	// A basis with a primal-infeasible basic solution (x1 = 7, x0 < 0) and
+	// a feasible but suboptimal basis (x0 = 0, x1 = 7) are both rejected.
+	for _, rb := range [][]BasisStatus{
+		{Upper, Basic, Lower},
+		{Upper, Basic, Basic},
+	} {
+		cb := []BasisStatus{Basic, Basic}
+		if rb[2] == Basic {
+			cb[0] = Lower
+		}
+		exact, err = model.isExactlyOptimal(cb, rb)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exact {
+			t.Fatalf("basis %v, %v was incorrectly recognized as optimal", cb, rb)
+		}
+	}
+
+	// Solve the model and check the basis HiGHS returns.
+	soln, exact, err := model.SolveExactCheck()
+	checkErr(t, err)
+	if !exact {
+		t.Fatalf("HiGHS's solution %v was not recognized as exact", soln.ColumnPrimal)
+	}
+
+	// Negative and non-finite scale factors are rejected.
+	for _, scale := range []float64{-2.0, math.NaN(), math.Inf(1)} {
+		scaled := *model
+		scaled.RowScale = []float64{1.0, scale, 1.0}
+		if _, err := scaled.isExactlyOptimal(colBasis, rowBasis); err == nil {
+			t.Fatalf("expected isExactlyOptimal to reject a scale factor of %v", scale)
+		}
+		if _, _, err := scaled.SolveExactCheck(); err == nil {
+			t.Fatalf("expected SolveExactCheck to reject a scale factor of %v", scale)
+		}
+	}
+}
@@ -3,7 +3,11 @@
 
 package highs
 
-import "testing"
+import (
+	"errors"
+	"math"
+	"testing"
+)
 
 // TestMinimalAPIMaxMIP mimics the third test in HiGHS's minimal_api function
 // from examples/call_highs_from_c.c:
@@ -83,3 +87,175 @@ func TestMIPModelToRawModel(t *testing.T) {
 	compSlices(t, "ColumnPrimal", soln.ColumnPrimal, []float64{3.0, 2.0})
 	compSlices(t, "RowPrimal", soln.RowPrimal, []float64{1.0, 5.0})
 }
+
+// TestAddIntegerColumn tests that a knapsack model built one column at a time
+// with AddIntegerColumn yields an integral optimum.
+func TestAddIntegerColumn(t *testing.T) {
+	// Prepare the model.
+	values := []float64{10, 13, 7, 8, 15, 4, 9, 12, 6, 11, 5, 14}
+	weights := []float64{5, 7, 4, 5, 8, 3, 5, 7, 3, 6, 3, 8}
+	var model Model
+	model.AddDenseRow(math.Inf(-1), nil, 30.0)
+	for i, v := range values {
+		model.AddIntegerColumn(-v, 0.0, 1.0, []float64{weights[i]})
+	}
+
+	// Solve the model and validate the solution.
+	soln := solveRaw(t, &model)
+	if math.Abs(soln.Objective+56.0) > 1e-6 {
+		t.Fatalf("objective value was %v but should have been -56", soln.Objective)
+	}
+	for c, v := range soln.ColumnPrimal {
+		if math.Abs(v-math.Round(v)) > 1e-9 {
+			t.Fatalf("column %d has non-integral value %v", c, v)
+		}
+	}
+}
+
+// TestSetTimeLimit tests that a MIP solve cut short by SetTimeLimit reports a
+// TimeLimit status rather than Optimal.
+func TestSetTimeLimit(t *testing.T) {
+	// Construct a multidimensional knapsack problem that HiGHS cannot
+	// solve instantly.
+	model := multiKnapsackModel(60, 5)
+
+	// Solve with a tiny time limit.
+	raw := mustToRawModel(t, model)
+	checkErr(t, raw.SetStringOption("presolve", "off"))
+	checkErr(t, raw.SetTimeLimit(1e-9))
+	soln, err := raw.Solve()
+	var cs CallStatus
+	if err != nil && !(errors.As(err, &cs) && cs.IsWarning()) {
+		t.Fatal(err)
+	}
+	if soln.Status != TimeLimit {
+		t.Fatalf("expected status TimeLimit but saw %v", soln.Status)
+	}
+
+	// Nonpositive limits are rejected.
+	if raw.SetTimeLimit(0.0) == nil {
+		t.Fatal("expected SetTimeLimit to reject a zero time limit")
+	}
+}
+
+// TestEnumerateFeasible tests that EnumerateFeasible finds all three feasible
+// points of min x_0 + 2x_1 s.t. 1 <= x_0 + x_1 with binary x_0 and x_1.
+func TestEnumerateFeasible(t *testing.T) {
+	var model Model
+	model.ColCosts = []float64{1.0, 2.0}
+	model.ColLower = []float64{0.0, 0.0}
+	model.ColUpper = []float64{1.0, 1.0}
+	model.VarTypes = []VariableType{IntegerType, IntegerType}
+	model.AddDenseRow(1.0, []float64{1.0, 1.0}, math.Inf(1))
+	points, err := model.EnumerateFeasible(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 3 {
+		t.Fatalf("expected 3 feasible points but saw %v", points)
+	}
+	for i, exp := range [][]float64{{1.0, 0.0}, {0.0, 1.0}, {1.0, 1.0}} {
+		compSlices(t, "point", points[i], exp)
+	}
+
+	// The number of points can be capped.
+	points, err = model.EnumerateFeasible(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("expected 2 feasible points but saw %v", points)
+	}
+
+	// Row scaling does not change the points found.
+	model.RowScale = []float64{4.0}
+	points, err = model.EnumerateFeasible(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 3 {
+		t.Fatalf("expected 3 feasible points but saw %v", points)
+	}
+	for i, exp := range [][]float64{{1.0, 0.0}, {0.0, 1.0}, {1.0, 1.0}} {
+		compSlices(t, "point", points[i], exp)
+	}
+
+	// Column scaling is rejected.
+	model.ColScale = []float64{2.0, 0.0}
+	if _, err = model.EnumerateFeasible(0); err == nil {
+		t.Fatal("expected EnumerateFeasible to reject a scaled column")
+	}
+}
+
+// TestMipGap tests that solving a MIP to a loose gap tolerance reports a gap
+// within that tolerance and a finite bound, and that LPs report neither.
+func TestMipGap(t *testing.T) {
+	raw := mustToRawModel(t, knapsackModel())
+	checkErr(t, raw.SetFloat64Option("mip_rel_gap", 0.5))
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.MipGap < 0.0 || soln.MipGap > 0.5 {
+		t.Fatalf("expected a MIP gap in [0, 0.5] but saw %v", soln.MipGap)
+	}
+	if math.IsNaN(soln.BestBound) || math.IsInf(soln.BestBound, 0) || soln.BestBound > soln.Objective {
+		t.Fatalf("unexpected best bound %v for objective value %v", soln.BestBound, soln.Objective)
+	}
+
+	// LPs have no MIP gap or bound.
+	soln = solveRaw(t, minimalAPIModel(false))
+	if !math.IsNaN(soln.MipGap) || !math.IsNaN(soln.BestBound) {
+		t.Fatalf("expected NaN gap and bound for an LP but saw %v and %v",
+			soln.MipGap, soln.BestBound)
+	}
+}
+
+// TestRelaxationSize tests that RelaxationSize reports a MIP's dimensions and
+// number of integer columns.
+func TestRelaxationSize(t *testing.T) {
+	model := minimalAPIModel(false)
+	model.VarTypes = []VariableType{IntegerType, ContinuousType}
+	cols, rows, nz, intCols := model.RelaxationSize()
+	if cols != 2 || rows != 3 || nz != 5 || intCols != 1 {
+		t.Fatalf("expected (2, 3, 5, 1) but saw (%d, %d, %d, %d)", cols, rows, nz, intCols)
+	}
+
+	// Duplicate coefficients are counted once.
+	model.ConstMatrix = append(model.ConstMatrix, Nonzero{2, 1, 4.0})
+	if _, _, nz, _ = model.RelaxationSize(); nz != 5 {
+		t.Fatalf("expected 5 nonzeros but saw %d", nz)
+	}
+	_, _, _, intCols = knapsackModel().RelaxationSize()
+	if intCols != 12 {
+		t.Fatalf("expected 12 integer columns but saw %d", intCols)
+	}
+}
+
+// TestSetSolution tests that HiGHS accepts a starting MIP solution.
+func TestSetSolution(t *testing.T) {
+	// Provide an optimal solution then give HiGHS no time to find one of
+	// its own.
+	raw := mustToRawModel(t, knapsackModel())
+	checkErr(t, raw.SetStringOption("presolve", "off"))
+	checkErr(t, raw.SetSolution([]float64{1, 1, 1, 0, 1, 0, 0, 0, 1, 0, 1, 0}))
+	checkErr(t, raw.SetTimeLimit(1e-9))
+	soln, err := raw.Solve()
+	checkErr(t, err)
+
+	// Confirm that HiGHS reports a feasible solution with the optimal
+	// objective value.
+	pss, err := soln.GetIntInfo("primal_solution_status")
+	checkErr(t, err)
+	if pss != 2 {
+		t.Fatalf("expected primal_solution_status 2 (feasible) but saw %d", pss)
+	}
+	if math.Abs(soln.Objective+56.0) > 1e-6 {
+		t.Fatalf("expected objective -56 but saw %v", soln.Objective)
+	}
+
+	// Incomplete solutions are rejected.
+	if raw.SetSolution([]float64{1, math.NaN()}) == nil {
+		t.Fatal("expected SetSolution to reject a short solution")
+	}
+}
@@ -0,0 +1,935 @@
+// This file provides methods that analyze the structure of a high-level model
+// without solving it.
+
+package highs
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"math"
+	"sort"
+	"strings"
+)
+
+// IsNetworkFlow reports whether a model's constraint matrix is a node-arc
+// incidence matrix, that is, whether each column contains at most one +1
+// coefficient, at most one −1 coefficient, and no other nonzero coefficients.
+// Such models represent network-flow problems.
+func (m *Model) IsNetworkFlow() bool {
+	nonzeros, err := filterNonzeros(m.ConstMatrix, false)
+	if err != nil {
+		return false
+	}
+	plus := make(map[int]bool)
+	minus := make(map[int]bool)
+	for _, nz := range nonzeros {
+		switch nz.Val {
+		case 0.0:
+			// Explicit zeros do not affect the structure.
+		case 1.0:
+			if plus[nz.Col] {
+				return false
+			}
+			plus[nz.Col] = true
+		case -1.0:
+			if minus[nz.Col] {
+				return false
+			}
+			minus[nz.Col] = true
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// canonicalBound maps all bounds that HiGHS would treat as infinite, given an
+// infinity threshold, to infinity.
+func canonicalBound(b, inf float64) float64 {
+	switch {
+	case b >= inf:
+		return math.Inf(1)
+	case b <= -inf:
+		return math.Inf(-1)
+	default:
+		return b
+	}
+}
+
+// hashNonzeros writes a canonical form of a sparse matrix to a hash: sorted
+// by row then column, with duplicates resolved and explicit zeros removed.
+func hashNonzeros(h hash.Hash, nz []Nonzero) {
+	sorted, err := filterNonzeros(nz, false)
+	if err != nil {
+		sorted = nz // Invalid matrices are hashed as is.
+	}
+	n := 0
+	for _, v := range sorted {
+		if v.Val != 0.0 {
+			n++
+		}
+	}
+	binary.Write(h, binary.LittleEndian, int64(n))
+	for _, v := range sorted {
+		if v.Val == 0.0 {
+			continue
+		}
+		binary.Write(h, binary.LittleEndian, int64(v.Row))
+		binary.Write(h, binary.LittleEndian, int64(v.Col))
+		binary.Write(h, binary.LittleEndian, v.Val)
+	}
+}
+
+// Hash returns a hexadecimal SHA-256 hash of a model's mathematical content,
+// which is suitable as a key for caching solutions.  Models that ToRawModel
+// would convert to the same low-level model hash identically, regardless of
+// the order in which their nonzeros were specified, whether omitted bounds
+// were left empty or given explicitly, how infinite bounds were expressed
+// relative to InfinityBound, and whether unscaled columns and rows were given
+// a zero scale factor or none at all.  InfinityBound, ColScale, and RowScale
+// are included in the hash, but the other solver options are not.
+func (m *Model) Hash() string {
+	h := sha256.New()
+	nr, nc := m.modelSize()
+	binary.Write(h, binary.LittleEndian, []int64{int64(nr), int64(nc)})
+	binary.Write(h, binary.LittleEndian, m.Maximize)
+	binary.Write(h, binary.LittleEndian, m.Offset)
+	inf := infiniteBound
+	if m.InfinityBound != 0.0 {
+		inf = m.InfinityBound
+	}
+	binary.Write(h, binary.LittleEndian, inf)
+
+	// Hash the per-column and per-row data, filling in defaults just as
+	// ToRawModel does.
+	writeFloats := func(xs []float64, n int, dflt float64, bound bool) {
+		ys, ok := expandToLen(n, xs, dflt)
+		if !ok {
+			// Distinguish inconsistent lengths from consistent ones.
+			ys = append(append([]float64{}, xs...), math.NaN())
+		}
+		for _, x := range ys {
+			if bound {
+				x = canonicalBound(x, inf)
+			}
+			binary.Write(h, binary.LittleEndian, x)
+		}
+	}
+	writeFloats(m.ColCosts, nc, 1.0, false)
+	writeFloats(m.ColLower, nc, math.Inf(-1), true)
+	writeFloats(m.ColUpper, nc, math.Inf(1), true)
+	writeFloats(m.RowLower, nr, math.Inf(-1), true)
+	writeFloats(m.RowUpper, nr, math.Inf(1), true)
+	unitScale := func(xs []float64) []float64 {
+		ys := make([]float64, len(xs))
+		for i, x := range xs {
+			ys[i] = x
+			if x == 0.0 {
+				ys[i] = 1.0 // ToRawModel does not scale by zero.
+			}
+		}
+		return ys
+	}
+	writeFloats(unitScale(m.ColScale), nc, 1.0, false)
+	writeFloats(unitScale(m.RowScale), nr, 1.0, false)
+	vts, ok := expandToLen(nc, m.VarTypes, ContinuousType)
+	if !ok {
+		vts = m.VarTypes
+	}
+	for _, vt := range vts {
+		binary.Write(h, binary.LittleEndian, int64(vt))
+	}
+
+	// Hash the sparse matrices.
+	hashNonzeros(h, m.ConstMatrix)
+	hashNonzeros(h, m.HessianMatrix)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// RedundantRows returns the indices of the rows (constraints) that can be
+// removed together without changing a model's feasible region.  Rows are
+// considered in order.  A row is deemed redundant if, after the row and all
+// previously identified redundant rows are removed, minimizing and maximizing
+// the row's activity over the remaining constraints stays within the row's
+// bounds, to within a tolerance.  Consequently, of a set of duplicate rows,
+// all but the last are reported.  RedundantRows solves two linear programs
+// per row.
+// Integrality and quadratic terms are ignored, so the test is performed on the
+// model's continuous relaxation; a row redundant in the relaxation is also
+// redundant in the original model.
+func (m *Model) RedundantRows(tol float64) ([]int, error) {
+	// Gather the model's row bounds and the coefficients in each row.
+	cost, _, _, rowLower, rowUpper, err := m.denseVectors()
+	if err != nil {
+		return nil, err
+	}
+	nc := len(cost)
+	if nc == 0 {
+		return nil, nil
+	}
+	nonzeros, err := filterNonzeros(m.ConstMatrix, false)
+	if err != nil {
+		return nil, err
+	}
+	rowCoeffs := make([][]Nonzero, len(rowLower))
+	for _, nz := range nonzeros {
+		rowCoeffs[nz.Row] = append(rowCoeffs[nz.Row], nz)
+	}
+
+	// Construct the continuous relaxation of the model.
+	relax := *m
+	relax.VarTypes = nil
+	relax.HessianMatrix = nil
+	relax.Offset = 0.0
+	relax.ColScale = nil
+	relax.RowScale = nil
+	raw, err := relax.ToRawModel()
+	if err != nil {
+		return nil, err
+	}
+
+	// optimizeRow minimizes or maximizes the activity of the current row.
+	optimizeRow := func(max bool) (float64, bool, error) {
+		err := raw.SetMaximization(max)
+		if err != nil {
+			return 0.0, false, err
+		}
+		soln, err := raw.Solve()
+		if err != nil {
+			return 0.0, false, err
+		}
+		switch soln.Status {
+		case Optimal:
+			return soln.Objective, true, nil
+		case Infeasible:
+			return 0.0, false, errors.New("the model is infeasible")
+		default:
+			return 0.0, false, nil // Unbounded or undetermined
+		}
+	}
+
+	// Test each row in turn.
+	var redundant []int
+	mInf, pInf := math.Inf(-1), math.Inf(1)
+	for r := range rowLower {
+		// Remove the row and make its activity the objective
+		// function.
+		err = raw.changeRowBounds(r, mInf, pInf, "RedundantRows")
+		if err != nil {
+			return nil, err
+		}
+		obj := make([]float64, nc)
+		for _, nz := range rowCoeffs[r] {
+			obj[nz.Col] = nz.Val
+		}
+		err = raw.SetColumnCosts(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		// Determine if the row's activity is implicitly bounded.
+		isRed := true
+		if math.Abs(rowLower[r]) < infiniteBound {
+			v, ok, err := optimizeRow(false)
+			if err != nil {
+				return nil, err
+			}
+			isRed = ok && v >= rowLower[r]-tol
+		}
+		if isRed && math.Abs(rowUpper[r]) < infiniteBound {
+			v, ok, err := optimizeRow(true)
+			if err != nil {
+				return nil, err
+			}
+			isRed = ok && v <= rowUpper[r]+tol
+		}
+		if isRed {
+			// Leave the row removed while testing the rest.
+			redundant = append(redundant, r)
+			continue
+		}
+
+		// Restore the row.
+		err = raw.changeRowBounds(r, rowLower[r], rowUpper[r], "RedundantRows")
+		if err != nil {
+			return nil, err
+		}
+	}
+	return redundant, nil
+}
+
+// MatrixRank returns the numerical rank of a model's constraint matrix.  A
+// rank less than the number of rows indicates that some constraints are
+// linearly dependent.  MatrixRank performs Gaussian elimination with complete
+// pivoting on a dense copy of the matrix and is therefore intended for
+// diagnosing small to moderately sized models.
+func (m *Model) MatrixRank() (int, error) {
+	// Construct a dense copy of the constraint matrix.
+	nonzeros, err := filterNonzeros(m.ConstMatrix, false)
+	if err != nil {
+		return 0, err
+	}
+	nr, nc := m.modelSize()
+	a := make([][]float64, nr)
+	for r := range a {
+		a[r] = make([]float64, nc)
+	}
+	maxAbs := 0.0
+	for _, nz := range nonzeros {
+		a[nz.Row][nz.Col] = nz.Val
+		maxAbs = math.Max(maxAbs, math.Abs(nz.Val))
+	}
+	dim := nr
+	if nc > dim {
+		dim = nc
+	}
+	tol := float64(dim) * maxAbs * 0x1p-52
+
+	// Eliminate one row and column per iteration, always pivoting on the
+	// largest remaining element.
+	rank := 0
+	for rank < nr && rank < nc {
+		pr, pc := rank, rank
+		big := 0.0
+		for r := rank; r < nr; r++ {
+			for c := rank; c < nc; c++ {
+				if v := math.Abs(a[r][c]); v > big {
+					big, pr, pc = v, r, c
+				}
+			}
+		}
+		if big <= tol {
+			break
+		}
+		a[rank], a[pr] = a[pr], a[rank]
+		for r := range a {
+			a[r][rank], a[r][pc] = a[r][pc], a[r][rank]
+		}
+		for r := rank + 1; r < nr; r++ {
+			f := a[r][rank] / a[rank][rank]
+			if f == 0.0 {
+				continue
+			}
+			for c := rank; c < nc; c++ {
+				a[r][c] -= f * a[rank][c]
+			}
+		}
+		rank++
+	}
+	return rank, nil
+}
+
+// nonzeroMap maps each coordinate of a sparse matrix to its nonzero value.
+// Explicit zeros are omitted, and duplicates are resolved as in ToRawModel.
+func nonzeroMap(nz []Nonzero) map[[2]int]float64 {
+	sorted, err := filterNonzeros(nz, false)
+	if err != nil {
+		sorted = nz
+	}
+	nzMap := make(map[[2]int]float64, len(sorted))
+	for _, v := range sorted {
+		if v.Val != 0.0 {
+			nzMap[[2]int{v.Row, v.Col}] = v.Val
+		}
+	}
+	return nzMap
+}
+
+// diffNonzeros compares two sparse matrices and reports, in row-major order,
+// the values that differ at each coordinate for which keep returns true.
+// Missing coordinates are treated as zero.
+func diffNonzeros(a, b []Nonzero, keep func(r, c int) bool, report func(r, c int, va, vb float64)) {
+	aMap, bMap := nonzeroMap(a), nonzeroMap(b)
+	coords := make([][2]int, 0, len(aMap)+len(bMap))
+	for rc := range aMap {
+		coords = append(coords, rc)
+	}
+	for rc := range bMap {
+		if _, ok := aMap[rc]; !ok {
+			coords = append(coords, rc)
+		}
+	}
+	sort.Slice(coords, func(i, j int) bool {
+		if coords[i][0] != coords[j][0] {
+			return coords[i][0] < coords[j][0]
+		}
+		return coords[i][1] < coords[j][1]
+	})
+	for _, rc := range coords {
+		va, vb := aMap[rc], bMap[rc]
+		if va != vb && keep(rc[0], rc[1]) {
+			report(rc[0], rc[1], va, vb)
+		}
+	}
+}
+
+// DiffModels returns a human-readable description of each difference between
+// two models: objective sense and offset, added and removed columns and rows,
+// and changed names, costs, bounds, variable types, and matrix coefficients.
+// Columns are identified by name (see Records).  Omitted values are compared
+// using the same defaults as ToRawModel, and solver options are ignored.  An
+// empty result indicates that the models are equivalent.
+func DiffModels(a, b *Model) []string {
+	var diffs []string
+	add := func(format string, args ...interface{}) {
+		diffs = append(diffs, fmt.Sprintf(format, args...))
+	}
+
+	// Compare the objective functions' sense and offset.
+	sense := map[bool]string{false: "minimize", true: "maximize"}
+	if a.Maximize != b.Maximize {
+		add("objective sense changed from %s to %s", sense[a.Maximize], sense[b.Maximize])
+	}
+	if a.Offset != b.Offset {
+		add("objective offset changed from %v to %v", a.Offset, b.Offset)
+	}
+
+	// Expand both models' vectors to their full lengths.
+	aCost, aColLower, aColUpper, aRowLower, aRowUpper, err := a.denseVectors()
+	if err != nil {
+		add("first model is invalid: %v", err)
+		return diffs
+	}
+	bCost, bColLower, bColUpper, bRowLower, bRowUpper, err := b.denseVectors()
+	if err != nil {
+		add("second model is invalid: %v", err)
+		return diffs
+	}
+	aTypes, ok := expandToLen(len(aCost), a.VarTypes, ContinuousType)
+	if !ok {
+		add("first model is invalid: inconsistent column counts")
+		return diffs
+	}
+	bTypes, ok := expandToLen(len(bCost), b.VarTypes, ContinuousType)
+	if !ok {
+		add("second model is invalid: inconsistent column counts")
+		return diffs
+	}
+
+	// Compare the columns.
+	diffBound := func(what string, va, vb float64) {
+		va, vb = canonicalBound(va, infiniteBound), canonicalBound(vb, infiniteBound)
+		if va != vb {
+			add("%s changed from %v to %v", what, va, vb)
+		}
+	}
+	for c := 0; c < len(aCost) || c < len(bCost); c++ {
+		switch {
+		case c >= len(bCost):
+			add("column %s removed", a.colName(c))
+			continue
+		case c >= len(aCost):
+			add("column %s added", b.colName(c))
+			continue
+		}
+		name := a.colName(c)
+		if bName := b.colName(c); bName != name {
+			add("column %s renamed to %s", name, bName)
+		}
+		if aCost[c] != bCost[c] {
+			add("column %s: cost changed from %v to %v", name, aCost[c], bCost[c])
+		}
+		diffBound("column "+name+": lower bound", aColLower[c], bColLower[c])
+		diffBound("column "+name+": upper bound", aColUpper[c], bColUpper[c])
+		if aTypes[c] != bTypes[c] {
+			add("column %s: type changed from %v to %v", name, aTypes[c], bTypes[c])
+		}
+	}
+
+	// Compare the rows.
+	for r := 0; r < len(aRowLower) || r < len(bRowLower); r++ {
+		switch {
+		case r >= len(bRowLower):
+			add("row %d removed", r)
+		case r >= len(aRowLower):
+			add("row %d added", r)
+		default:
+			diffBound(fmt.Sprintf("row %d: lower bound", r), aRowLower[r], bRowLower[r])
+			diffBound(fmt.Sprintf("row %d: upper bound", r), aRowUpper[r], bRowUpper[r])
+		}
+	}
+
+	// Compare the coefficients of the rows and columns the models have in
+	// common.
+	common := func(r, c int) bool {
+		return r < len(aRowLower) && r < len(bRowLower) &&
+			c < len(aCost) && c < len(bCost)
+	}
+	diffNonzeros(a.ConstMatrix, b.ConstMatrix, common,
+		func(r, c int, va, vb float64) {
+			add("row %d: coefficient of column %s changed from %v to %v",
+				r, a.colName(c), va, vb)
+		})
+	bothCols := func(r, c int) bool {
+		return c < len(aCost) && c < len(bCost)
+	}
+	diffNonzeros(a.HessianMatrix, b.HessianMatrix, bothCols,
+		func(r, c int, va, vb float64) {
+			add("Hessian entry (%s, %s) changed from %v to %v",
+				a.colName(r), a.colName(c), va, vb)
+		})
+	return diffs
+}
+
+// feasTol is the absolute tolerance Evaluate uses when checking bounds and
+// integrality.  It matches HiGHS's default primal feasibility tolerance.
+const feasTol = 1e-7
+
+// Evaluate computes, without solving, a model's objective value and each row's
+// activity (Ax) at a given point and reports whether the point satisfies all
+// of the model's bounds and integrality requirements.  An error is returned if
+// the model is malformed or the point has the wrong number of elements.
+func (m *Model) Evaluate(x []float64) (objective float64, rowActivity []float64, feasible bool, err error) {
+	cost, colLower, colUpper, rowLower, rowUpper, err := m.denseVectors()
+	if err != nil {
+		return 0.0, nil, false, err
+	}
+	if len(x) != len(cost) {
+		return 0.0, nil, false, fmt.Errorf("expected %d values but received %d", len(cost), len(x))
+	}
+	vts, ok := expandToLen(len(cost), m.VarTypes, ContinuousType)
+	if !ok {
+		return 0.0, nil, false, errors.New("inconsistent column counts")
+	}
+	aNz, err := filterNonzeros(m.ConstMatrix, false)
+	if err != nil {
+		return 0.0, nil, false, err
+	}
+	qNz, err := filterNonzeros(m.HessianMatrix, true)
+	if err != nil {
+		return 0.0, nil, false, err
+	}
+
+	// Compute the objective value, including any quadratic term.  Only
+	// the upper triangle of the Hessian is stored.
+	objective = m.Offset
+	for c, v := range x {
+		objective += cost[c] * v
+	}
+	for _, nz := range qNz {
+		if nz.Row == nz.Col {
+			objective += 0.5 * nz.Val * x[nz.Row] * x[nz.Col]
+		} else {
+			objective += nz.Val * x[nz.Row] * x[nz.Col]
+		}
+	}
+
+	// Compute the row activities.
+	rowActivity = make([]float64, len(rowLower))
+	for _, nz := range aNz {
+		rowActivity[nz.Row] += nz.Val * x[nz.Col]
+	}
+
+	// Check feasibility.
+	feasible = true
+	for r, v := range rowActivity {
+		if v < rowLower[r]-feasTol || v > rowUpper[r]+feasTol {
+			feasible = false
+		}
+	}
+	for c, v := range x {
+		inBounds := v >= colLower[c]-feasTol && v <= colUpper[c]+feasTol
+		integral := math.Abs(v-math.Round(v)) <= feasTol
+		isZero := math.Abs(v) <= feasTol
+		switch vts[c] {
+		case IntegerType, ImplicitIntegerType:
+			feasible = feasible && inBounds && integral
+		case SemiContinuousType:
+			feasible = feasible && (isZero || inBounds)
+		case SemiIntegerType:
+			feasible = feasible && (isZero || (inBounds && integral))
+		default:
+			feasible = feasible && inBounds
+		}
+	}
+	return objective, rowActivity, feasible, nil
+}
+
+// HasSymmetry reports whether a model contains at least two interchangeable
+// columns, that is, columns with identical costs, bounds, variable types, and
+// constraint-matrix coefficients.  Such symmetry can make mixed-integer
+// models slow to solve without symmetry-breaking constraints.  HasSymmetry is
+// a heuristic: it detects only identical columns, not more general
+// permutation symmetries, and it ignores columns that appear in the Hessian.
+func (m *Model) HasSymmetry() bool {
+	cost, colLower, colUpper, _, _, err := m.denseVectors()
+	if err != nil {
+		return false
+	}
+	vts, ok := expandToLen(len(cost), m.VarTypes, ContinuousType)
+	if !ok {
+		return false
+	}
+	nonzeros, err := filterNonzeros(m.ConstMatrix, false)
+	if err != nil {
+		return false
+	}
+
+	// Gather each column's coefficients in row order, skipping explicit
+	// zeros.
+	coeffs := make([][]Nonzero, len(cost))
+	for _, nz := range nonzeros {
+		if nz.Val != 0.0 {
+			coeffs[nz.Col] = append(coeffs[nz.Col], nz)
+		}
+	}
+	quadratic := make(map[int]bool)
+	for _, nz := range m.HessianMatrix {
+		quadratic[nz.Row] = true
+		quadratic[nz.Col] = true
+	}
+
+	// Look for two columns with the same signature.
+	seen := make(map[string]bool, len(cost))
+	for c := range cost {
+		if quadratic[c] {
+			continue
+		}
+		h := sha256.New()
+		binary.Write(h, binary.LittleEndian, []float64{cost[c],
+			canonicalBound(colLower[c], infiniteBound), canonicalBound(colUpper[c], infiniteBound)})
+		binary.Write(h, binary.LittleEndian, int64(vts[c]))
+		for _, nz := range coeffs[c] {
+			binary.Write(h, binary.LittleEndian, int64(nz.Row))
+			binary.Write(h, binary.LittleEndian, nz.Val)
+		}
+		sig := string(h.Sum(nil))
+		if seen[sig] {
+			return true
+		}
+		seen[sig] = true
+	}
+	return false
+}
+
+// formatExpr renders the sum of vals[i]*vars[i] in algebraic form, such as
+// "c0 - 2*c1".  An empty entry in vars denotes a constant term.
+func formatExpr(vals []float64, vars []string) string {
+	var expr strings.Builder
+	for i, v := range vals {
+		switch {
+		case i == 0 && v < 0.0:
+			expr.WriteString("-")
+			v = -v
+		case i > 0 && v < 0.0:
+			expr.WriteString(" - ")
+			v = -v
+		case i > 0:
+			expr.WriteString(" + ")
+		}
+		switch {
+		case vars[i] == "":
+			fmt.Fprintf(&expr, "%v", v)
+		case v != 1.0:
+			fmt.Fprintf(&expr, "%v*%s", v, vars[i])
+		default:
+			expr.WriteString(vars[i])
+		}
+	}
+	if len(vals) == 0 {
+		expr.WriteString("0")
+	}
+	return expr.String()
+}
+
+// formatConstraint renders a constraint lower ≤ expr ≤ upper, omitting
+// infinite bounds, such as "5 <= c0 + 2*c1 <= 15" or "c1 >= 1".
+func formatConstraint(expr string, lower, upper float64) string {
+	noLower, noUpper := lower <= -infiniteBound, upper >= infiniteBound
+	switch {
+	case lower == upper:
+		return fmt.Sprintf("%s = %v", expr, lower)
+	case noLower && noUpper:
+		return fmt.Sprintf("%s is free", expr)
+	case noLower:
+		return fmt.Sprintf("%s <= %v", expr, upper)
+	case noUpper:
+		return fmt.Sprintf("%s >= %v", expr, lower)
+	default:
+		return fmt.Sprintf("%v <= %s <= %v", lower, expr, upper)
+	}
+}
+
+// String renders a model in readable algebraic form, using column names when
+// available: the objective function, followed by the constraints, the finite
+// column bounds, and the columns of each non-continuous type.  String
+// implements the fmt.Stringer interface.
+func (m *Model) String() string {
+	cost, colLower, colUpper, rowLower, rowUpper, err := m.denseVectors()
+	if err != nil {
+		return fmt.Sprintf("invalid model: %v", err)
+	}
+	aNz, err := filterNonzeros(m.ConstMatrix, false)
+	if err != nil {
+		return fmt.Sprintf("invalid model: %v", err)
+	}
+	qNz, err := filterNonzeros(m.HessianMatrix, true)
+	if err != nil {
+		return fmt.Sprintf("invalid model: %v", err)
+	}
+	var sb strings.Builder
+
+	// Render the objective function: linear terms, quadratic terms
+	// (½xᵀQx with Q upper triangular), and the constant offset.
+	if m.Maximize {
+		sb.WriteString("max ")
+	} else {
+		sb.WriteString("min ")
+	}
+	var vals []float64
+	var vars []string
+	for c, v := range cost {
+		if v != 0.0 {
+			vals = append(vals, v)
+			vars = append(vars, m.colName(c))
+		}
+	}
+	for _, nz := range qNz {
+		switch {
+		case nz.Val == 0.0:
+			continue
+		case nz.Row == nz.Col:
+			vals = append(vals, nz.Val/2.0)
+			vars = append(vars, m.colName(nz.Col)+"^2")
+		default:
+			vals = append(vals, nz.Val)
+			vars = append(vars, m.colName(nz.Row)+"*"+m.colName(nz.Col))
+		}
+	}
+	if m.Offset != 0.0 {
+		vals = append(vals, m.Offset)
+		vars = append(vars, "")
+	}
+	sb.WriteString(formatExpr(vals, vars))
+
+	// Render each constraint.  filterNonzeros sorts each row's terms by
+	// column.
+	rowTerms := make([][]Nonzero, len(rowLower))
+	for _, nz := range aNz {
+		rowTerms[nz.Row] = append(rowTerms[nz.Row], nz)
+	}
+	if len(rowTerms) > 0 {
+		sb.WriteString("\ns.t.")
+	}
+	for r, terms := range rowTerms {
+		vals = vals[:0]
+		vars = vars[:0]
+		for _, nz := range terms {
+			vals = append(vals, nz.Val)
+			vars = append(vars, m.colName(nz.Col))
+		}
+		sb.WriteString("\n  ")
+		sb.WriteString(formatConstraint(formatExpr(vals, vars), rowLower[r], rowUpper[r]))
+	}
+
+	// Render the finite column bounds.
+	header := "\nbounds"
+	for c := range cost {
+		if colLower[c] <= -infiniteBound && colUpper[c] >= infiniteBound {
+			continue
+		}
+		sb.WriteString(header)
+		header = ""
+		sb.WriteString("\n  ")
+		sb.WriteString(formatConstraint(m.colName(c), colLower[c], colUpper[c]))
+	}
+
+	// Render the columns of each non-continuous type.
+	typeNames := []struct {
+		vt   VariableType
+		name string
+	}{
+		{IntegerType, "integer"},
+		{SemiContinuousType, "semi-continuous"},
+		{SemiIntegerType, "semi-integer"},
+		{ImplicitIntegerType, "implicit integer"},
+	}
+	for _, tn := range typeNames {
+		var cols []string
+		for c, vt := range m.VarTypes {
+			if vt == tn.vt {
+				cols = append(cols, m.colName(c))
+			}
+		}
+		if len(cols) > 0 {
+			fmt.Fprintf(&sb, "\n%s\n  %s", tn.name, strings.Join(cols, " "))
+		}
+	}
+	return sb.String()
+}
+
+// Validate checks a model for construction errors that can be detected
+// without invoking HiGHS.  It returns an error if the model's fields disagree
+// on the number of rows or columns, and it returns an error that names each
+// column and row with a NaN bound if there are any.  Otherwise, it returns an error that wraps ErrInfeasible and
+// names each offending column and row if any column's lower bound exceeds its
+// upper bound or any row's lower bound exceeds its upper bound.
+func (m *Model) Validate() error {
+	_, colLower, colUpper, rowLower, rowUpper, err := m.denseVectors()
+	if err != nil {
+		return err
+	}
+	var nan, crossed []string
+	for c := range colLower {
+		switch {
+		case math.IsNaN(colLower[c]) || math.IsNaN(colUpper[c]):
+			nan = append(nan, fmt.Sprintf("column %d (%s) has a NaN bound [%v, %v]",
+				c, m.colName(c), colLower[c], colUpper[c]))
+		case colLower[c] > colUpper[c]:
+			crossed = append(crossed, fmt.Sprintf("column %d (%s) has lower bound %v > upper bound %v",
+				c, m.colName(c), colLower[c], colUpper[c]))
+		}
+	}
+	for r := range rowLower {
+		switch {
+		case math.IsNaN(rowLower[r]) || math.IsNaN(rowUpper[r]):
+			nan = append(nan, fmt.Sprintf("row %d has a NaN bound [%v, %v]",
+				r, rowLower[r], rowUpper[r]))
+		case rowLower[r] > rowUpper[r]:
+			crossed = append(crossed, fmt.Sprintf("row %d has lower bound %v > upper bound %v",
+				r, rowLower[r], rowUpper[r]))
+		}
+	}
+	if len(nan) > 0 {
+		return errors.New(strings.Join(nan, "; "))
+	}
+	if len(crossed) > 0 {
+		return fmt.Errorf("%s: %w", strings.Join(crossed, "; "), ErrInfeasible)
+	}
+	return nil
+}
+
+// RelaxationSize reports a model's dimensions—its number of columns, rows,
+// and nonzero constraint coefficients—and the number of columns that are
+// subject to an integrality requirement (integer, semi-integer, or implicit
+// integer columns).  Relaxing integrality affects only the last of these.
+// Duplicate coefficients are counted once, as ToRawModel keeps only the last
+// of them, and explicit zeros are not counted.
+func (m *Model) RelaxationSize() (cols, rows, nz, intCols int) {
+	rows, cols = m.modelSize()
+	aNz, err := filterNonzeros(m.ConstMatrix, false)
+	if err != nil {
+		aNz = m.ConstMatrix // Invalid matrices are counted as is.
+	}
+	for _, v := range aNz {
+		if v.Val != 0.0 {
+			nz++
+		}
+	}
+	for _, vt := range m.VarTypes {
+		switch vt {
+		case IntegerType, SemiIntegerType, ImplicitIntegerType:
+			intCols++
+		}
+	}
+	return cols, rows, nz, intCols
+}
+
+// Sanitize scans a model for NaN and infinite values, which typically result
+// from bugs in the code that generated the model.  Constraint-matrix and
+// Hessian-matrix coefficients that are NaN or infinite are handled according
+// to a policy.  With SanitizeRemove, they are removed from the model, and
+// Sanitize returns the number of coefficients removed.  With SanitizeError,
+// the model is left unmodified, and the coefficients are reported by the
+// returned error.  Values that cannot be removed—NaN or infinite costs and
+// objective offsets and NaN bounds—are left in place and reported by the
+// returned error under either policy.  Infinite bounds are legitimate and are
+// left alone.
+func (m *Model) Sanitize(policy SanitizePolicy) (removed int, err error) {
+	if policy != SanitizeRemove && policy != SanitizeError {
+		return 0, fmt.Errorf("invalid SanitizePolicy value (%d)", policy)
+	}
+
+	// Remove or report bad coefficients.  Removal builds new slices so
+	// as not to modify the caller's backing arrays.
+	var bad []string
+	isBad := func(v float64) bool { return math.IsNaN(v) || math.IsInf(v, 0) }
+	keepGood := func(what string, nzs []Nonzero) []Nonzero {
+		var good []Nonzero
+		for _, nz := range nzs {
+			if isBad(nz.Val) {
+				if policy == SanitizeError {
+					bad = append(bad, fmt.Sprintf("%s coefficient (%d, %d) is %v",
+						what, nz.Row, nz.Col, nz.Val))
+				}
+				continue
+			}
+			good = append(good, nz)
+		}
+		if len(good) == len(nzs) || policy == SanitizeError {
+			return nzs
+		}
+		removed += len(nzs) - len(good)
+		return good
+	}
+	m.ConstMatrix = keepGood("matrix", m.ConstMatrix)
+	m.HessianMatrix = keepGood("Hessian", m.HessianMatrix)
+
+	// Report bad costs and bounds.
+	if isBad(m.Offset) {
+		bad = append(bad, fmt.Sprintf("offset is %v", m.Offset))
+	}
+	for c, v := range m.ColCosts {
+		if isBad(v) {
+			bad = append(bad, fmt.Sprintf("column %d (%s) has cost %v", c, m.colName(c), v))
+		}
+	}
+	for _, b := range []struct {
+		what string
+		vals []float64
+		name func(i int) string
+	}{
+		{"lower bound", m.ColLower, func(c int) string { return fmt.Sprintf("column %d (%s)", c, m.colName(c)) }},
+		{"upper bound", m.ColUpper, func(c int) string { return fmt.Sprintf("column %d (%s)", c, m.colName(c)) }},
+		{"lower bound", m.RowLower, func(r int) string { return fmt.Sprintf("row %d", r) }},
+		{"upper bound", m.RowUpper, func(r int) string { return fmt.Sprintf("row %d", r) }},
+	} {
+		for i, v := range b.vals {
+			if math.IsNaN(v) {
+				bad = append(bad, fmt.Sprintf("%s has %s %v", b.name(i), b.what, v))
+			}
+		}
+	}
+	if len(bad) > 0 {
+		return removed, fmt.Errorf("invalid model values: %s", strings.Join(bad, "; "))
+	}
+	return removed, nil
+}
+
+// RHS expresses a model's row bounds in the conventional form Ax <sense> b,
+// returning the right-hand side b and the sense of each row.  A row with only
+// an upper bound, only a lower bound, or equal bounds has that bound as its
+// right-hand side.  A row with distinct finite bounds is reported as
+// ConstraintRanged with its upper bound as the right-hand side; its lower
+// bound remains available in RowLower.  A row with no finite bounds is
+// reported as ConstraintFree with a right-hand side of zero.  RHS returns nil
+// slices if the model's fields disagree on the number of rows.
+func (m *Model) RHS() ([]float64, []ConstraintSense) {
+	_, _, _, rowLower, rowUpper, err := m.denseVectors()
+	if err != nil {
+		return nil, nil
+	}
+	rhs := make([]float64, len(rowLower))
+	senses := make([]ConstraintSense, len(rowLower))
+	for r, lo := range rowLower {
+		up := rowUpper[r]
+		noLower, noUpper := lo <= -infiniteBound, up >= infiniteBound
+		switch {
+		case lo == up:
+			rhs[r], senses[r] = lo, ConstraintEqual
+		case noLower && noUpper:
+			rhs[r], senses[r] = 0.0, ConstraintFree
+		case noLower:
+			rhs[r], senses[r] = up, ConstraintLessEqual
+		case noUpper:
+			rhs[r], senses[r] = lo, ConstraintGreaterEqual
+		default:
+			rhs[r], senses[r] = up, ConstraintRanged
+		}
+	}
+	return rhs, senses
+}
@@ -0,0 +1,280 @@
+// This file provides methods that construct new high-level models from
+// existing ones.
+
+package highs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// isContinuous reports whether all of a model's variables are continuous.
+func (m *Model) isContinuous() bool {
+	for _, vt := range m.VarTypes {
+		if vt != ContinuousType {
+			return false
+		}
+	}
+	return true
+}
+
+// Dual constructs the dual of a linear-programming model.  The dual contains
+// one nonnegative variable for each finite bound in the primal model, ordered
+// as follows: for each row, its lower bound (if finite) then its upper bound
+// (if finite); then, for each column, its lower bound (if finite) then its
+// upper bound (if finite).  The dual contains one equality constraint per
+// primal column.  A minimization primal produces a maximization dual and vice
+// versa.  Because of strong duality, if both models have optimal solutions,
+// their objective values are equal.
+func (m *Model) Dual() (*Model, error) {
+	// Ensure we were given a linear program.
+	if len(m.HessianMatrix) > 0 {
+		return nil, errors.New("the dual can be constructed only for linear programs, not quadratic programs")
+	}
+	if !m.isContinuous() {
+		return nil, errors.New("the dual can be constructed only for linear programs, not mixed-integer programs")
+	}
+
+	// Gather the primal model's data.
+	cost, colLower, colUpper, rowLower, rowUpper, err := m.denseVectors()
+	if err != nil {
+		return nil, err
+	}
+	nonzeros, err := filterNonzeros(m.ConstMatrix, false)
+	if err != nil {
+		return nil, err
+	}
+	primalRows := make([][]Nonzero, len(rowLower))
+	for _, nz := range nonzeros {
+		primalRows[nz.Row] = append(primalRows[nz.Row], nz)
+	}
+
+	// Negating the objective of a maximization primal turns it into a
+	// minimization primal.
+	sign := 1.0
+	if m.Maximize {
+		sign = -1.0
+	}
+
+	// Define a dual constraint for each primal column.
+	dual := &Model{
+		Maximize: !m.Maximize,
+		Offset:   m.Offset,
+		RowLower: make([]float64, len(cost)),
+		RowUpper: make([]float64, len(cost)),
+	}
+	for c, v := range cost {
+		dual.RowLower[c] = sign * v
+		dual.RowUpper[c] = sign * v
+	}
+
+	// Define a dual variable for each finite primal row or column bound.
+	// coeffs maps each dual row (primal column) to a coefficient.
+	addVar := func(obj float64, coeffs []Nonzero, scale float64) {
+		c := len(dual.ColCosts)
+		dual.ColCosts = append(dual.ColCosts, obj)
+		dual.ColLower = append(dual.ColLower, 0.0)
+		dual.ColUpper = append(dual.ColUpper, math.Inf(1))
+		for _, nz := range coeffs {
+			dual.ConstMatrix = append(dual.ConstMatrix,
+				Nonzero{Row: nz.Col, Col: c, Val: scale * nz.Val})
+		}
+	}
+	for r := range rowLower {
+		if math.Abs(rowLower[r]) < infiniteBound {
+			addVar(sign*rowLower[r], primalRows[r], 1.0)
+		}
+		if math.Abs(rowUpper[r]) < infiniteBound {
+			addVar(-sign*rowUpper[r], primalRows[r], -1.0)
+		}
+	}
+	for c := range cost {
+		unit := []Nonzero{{Col: c, Val: 1.0}}
+		if math.Abs(colLower[c]) < infiniteBound {
+			addVar(sign*colLower[c], unit, 1.0)
+		}
+		if math.Abs(colUpper[c]) < infiniteBound {
+			addVar(-sign*colUpper[c], unit, -1.0)
+		}
+	}
+	return dual, nil
+}
+
+// FixIntegersAndExtract returns a linear-programming model derived from a
+// mixed-integer model by fixing each integer, semi-integer, and
+// implicit-integer column to its (rounded) value in the given solution.  Each
+// semi-continuous column whose value is zero is fixed at zero; the others
+// become ordinary continuous columns.  Solving the resulting model yields the
+// dual values that HiGHS does not provide for a mixed-integer model.
+// Quadratic models are not supported.
+func (m *RawModel) FixIntegersAndExtract(soln *RawSolution) (*Model, error) {
+	model, err := m.toModel()
+	if err != nil {
+		return nil, err
+	}
+	if len(model.VarTypes) == 0 {
+		return model, nil
+	}
+	if len(soln.ColumnPrimal) != len(model.VarTypes) {
+		return nil, fmt.Errorf("the solution has %d column(s) but the model has %d",
+			len(soln.ColumnPrimal), len(model.VarTypes))
+	}
+	for c, vt := range model.VarTypes {
+		v := soln.ColumnPrimal[c]
+		switch vt {
+		case IntegerType, SemiIntegerType, ImplicitIntegerType:
+			v = math.Round(v)
+		case SemiContinuousType:
+			if math.Abs(v) > boundTol {
+				continue
+			}
+			v = 0.0
+		default:
+			continue
+		}
+		model.ColLower[c] = v
+		model.ColUpper[c] = v
+	}
+	model.VarTypes = nil
+	return model, nil
+}
+
+// ChebyshevCenter returns the center and radius of the largest ball inscribed
+// in the feasible region of a model's continuous relaxation.  The center is a
+// maximally interior feasible point, which is useful as a robust solution or
+// as a starting point.  ChebyshevCenter constructs and solves an auxiliary
+// linear program with one additional column, representing the radius, and one
+// row per finite row or column bound.  The Hessian and variable types are
+// ignored.  An error is returned if the feasible region is empty or
+// unbounded.
+func (m *Model) ChebyshevCenter() ([]float64, float64, error) {
+	// Gather the model's data.
+	cost, colLower, colUpper, rowLower, rowUpper, err := m.denseVectors()
+	if err != nil {
+		return nil, 0.0, err
+	}
+	nonzeros, err := filterNonzeros(m.ConstMatrix, false)
+	if err != nil {
+		return nil, 0.0, err
+	}
+	rows := make([][]Nonzero, len(rowLower))
+	for _, nz := range nonzeros {
+		rows[nz.Row] = append(rows[nz.Row], nz)
+	}
+
+	// Define free columns representing the center and a nonnegative
+	// column representing the radius, which is to be maximized.
+	nc := len(cost)
+	rad := nc
+	aux := &Model{
+		Maximize: true,
+		ColCosts: make([]float64, nc+1),
+		ColLower: make([]float64, nc+1),
+		ColUpper: make([]float64, nc+1),
+	}
+	for c := 0; c < nc; c++ {
+		aux.ColLower[c] = math.Inf(-1)
+		aux.ColUpper[c] = math.Inf(1)
+	}
+	aux.ColCosts[rad] = 1.0
+	aux.ColUpper[rad] = math.Inf(1)
+
+	// Require the ball to lie on the feasible side of each finite row or
+	// column bound: a·x - ‖a‖r ≥ lower and a·x + ‖a‖r ≤ upper.
+	addRow := func(coeffs []Nonzero, radCoeff, lower, upper float64) {
+		r := len(aux.RowLower)
+		for _, nz := range coeffs {
+			aux.ConstMatrix = append(aux.ConstMatrix, Nonzero{Row: r, Col: nz.Col, Val: nz.Val})
+		}
+		aux.ConstMatrix = append(aux.ConstMatrix, Nonzero{Row: r, Col: rad, Val: radCoeff})
+		aux.RowLower = append(aux.RowLower, lower)
+		aux.RowUpper = append(aux.RowUpper, upper)
+	}
+	addBounds := func(coeffs []Nonzero, lower, upper float64) {
+		norm := 0.0
+		for _, nz := range coeffs {
+			norm += nz.Val * nz.Val
+		}
+		norm = math.Sqrt(norm)
+		if math.Abs(lower) < infiniteBound {
+			addRow(coeffs, -norm, lower, math.Inf(1))
+		}
+		if math.Abs(upper) < infiniteBound {
+			addRow(coeffs, norm, math.Inf(-1), upper)
+		}
+	}
+	for r := range rowLower {
+		addBounds(rows[r], rowLower[r], rowUpper[r])
+	}
+	for c := range cost {
+		addBounds([]Nonzero{{Col: c, Val: 1.0}}, colLower[c], colUpper[c])
+	}
+
+	// Solve the auxiliary model.
+	raw, err := aux.ToRawModel()
+	if err != nil {
+		return nil, 0.0, err
+	}
+	soln, err := raw.Solve()
+	if err != nil {
+		return nil, 0.0, err
+	}
+	switch soln.Status {
+	case Optimal:
+		return soln.ColumnPrimal[:nc], soln.ColumnPrimal[rad], nil
+	case Infeasible:
+		return nil, 0.0, errors.New("the feasible region is empty")
+	case Unbounded:
+		return nil, 0.0, errors.New("the feasible region contains arbitrarily large balls")
+	default:
+		return nil, 0.0, fmt.Errorf("failed to find the Chebyshev center (status %s)", soln.Status)
+	}
+}
+
+// AnalyticCenter returns an approximation to the analytic center of the
+// feasible region of a model's continuous relaxation: a strictly interior
+// point that is useful as a robust reference solution or as a starting point.
+// AnalyticCenter discards the objective function, the Hessian, the variable
+// types, and the scale factors then solves the resulting feasibility problem
+// using the interior-point solver with presolve and crossover disabled, which
+// would otherwise move the solution to a vertex.
+func (m *Model) AnalyticCenter() ([]float64, error) {
+	// Construct a feasibility problem.
+	_, nc := m.modelSize()
+	feas := *m
+	feas.ColCosts = make([]float64, nc)
+	feas.Offset = 0.0
+	feas.HessianMatrix = nil
+	feas.VarTypes = nil
+	feas.ColScale = nil
+	feas.RowScale = nil
+	raw, err := feas.ToRawModel()
+	if err != nil {
+		return nil, err
+	}
+	for opt, v := range map[string]string{
+		"solver":        "ipm",
+		"run_crossover": "off",
+		"presolve":      "off",
+	} {
+		err = raw.SetStringOption(opt, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Solve the feasibility problem.
+	soln, err := raw.Solve()
+	if err != nil {
+		return nil, err
+	}
+	switch soln.Status {
+	case Optimal:
+		return soln.ColumnPrimal, nil
+	case Infeasible:
+		return nil, errors.New("the feasible region is empty")
+	default:
+		return nil, fmt.Errorf("failed to find the analytic center (status %s)", soln.Status)
+	}
+}
@@ -4,9 +4,15 @@
 package highs
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
+	"runtime"
+	"sync"
+	"time"
 )
 
 // #include "highs-externs.h"
@@ -25,6 +31,22 @@ type Model struct {
 	ConstMatrix   []Nonzero      // Sparse constraint matrix (per-row variable coefficients)
 	HessianMatrix []Nonzero      // Sparse, upper-triangular matrix of second partial derivatives of quadratic constraints
 	VarTypes      []VariableType // Type of each model variable
+	ColNames      []string       // Name of each column (optional)
+	ColScale      []float64      // Per-column scale factors (0=unscaled)
+	RowScale      []float64      // Per-row scale factors (0=unscaled)
+	Solution      []float64      // Primal column values stored by ApplySolution
+
+	// The following fields specify solver options.  A zero value or
+	// nil pointer indicates that HiGHS's default value should be used.
+	// Pointers are used for options for which zero is meaningful.
+	MIPHeuristicEffort     *float64              // Fraction of MIP effort to spend on primal heuristics (0 to 1)
+	SimplexCrash           SimplexCrash          // Heuristic for constructing the initial simplex basis
+	SimplexDualEdgeWeight  SimplexDualEdgeWeight // Dual simplex pricing strategy
+	IterationLimit         int                   // Maximum number of simplex or interior-point iterations
+	InfinityBound          float64               // Magnitude at or above which a bound is treated as infinite
+	PresolveReductionLimit *int                  // Maximum number of reductions presolve may perform (0=none)
+	PresolveMaxFillIn      *int                  // Maximum fill-in when presolve substitutes out implied-free columns
+	ImpliedFreeColumns     []int                 // Columns to hint to presolve as implied free (unsupported by HiGHS)
 }
 
 // AddDenseRow is a convenience function that lets the caller add to the model
@@ -47,6 +69,222 @@ func (m *Model) AddDenseRow(lb float64, coeffs []float64, ub float64) {
 	}
 }
 
+// padToLen extends a slice to length n by appending copies of v.  Slices of
+// length n or longer are returned unmodified.
+func padToLen[T any](n int, xs []T, v T) []T {
+	for len(xs) < n {
+		xs = append(xs, v)
+	}
+	return xs
+}
+
+// AddIntegerColumn is a convenience function that lets the caller add to the
+// model a single integer column's cost, lower bound, upper bound, and matrix
+// coefficients (one per row, specified densely, but stored sparsely).  Any
+// existing columns whose costs, bounds, or types were left unspecified
+// receive ToRawModel's defaults.
+func (m *Model) AddIntegerColumn(cost, lb, ub float64, coeffs []float64) {
+	_, c := m.modelSize()
+	m.ColCosts = append(padToLen(c, m.ColCosts, 1.0), cost)
+	m.ColLower = append(padToLen(c, m.ColLower, -Infinity()), lb)
+	m.ColUpper = append(padToLen(c, m.ColUpper, Infinity()), ub)
+	m.VarTypes = append(padToLen(c, m.VarTypes, ContinuousType), IntegerType)
+	for r, v := range coeffs {
+		if v == 0.0 {
+			continue
+		}
+		nz := Nonzero{
+			Row: r,
+			Col: c,
+			Val: v,
+		}
+		m.ConstMatrix = append(m.ConstMatrix, nz)
+	}
+}
+
+// A Matrix is a two-dimensional matrix of float64 values.  gonum's mat.Matrix
+// satisfies this interface, so gonum matrices can be passed to
+// NewModelFromMatrix without the highs package depending on gonum.
+type Matrix interface {
+	Dims() (r, c int)    // Number of rows and columns
+	At(i, j int) float64 // Value at row i, column j
+}
+
+// NewModelFromMatrix constructs a linear-programming model that minimizes cᵀx
+// subject to bLower ≤ Ax ≤ bUpper and xLower ≤ x ≤ xUpper.  Any of the bound
+// slices may be nil to select ToRawModel's defaults.  Only the nonzero
+// elements of A are stored.  If A additionally provides a gonum-style
+// DoNonZero method, this is used to visit A's nonzeros efficiently.
+func NewModelFromMatrix(c []float64, A Matrix, bLower, bUpper, xLower, xUpper []float64) *Model {
+	model := &Model{
+		ColCosts: c,
+		ColLower: xLower,
+		ColUpper: xUpper,
+		RowLower: bLower,
+		RowUpper: bUpper,
+	}
+	add := func(i, j int, v float64) {
+		if v != 0.0 {
+			model.ConstMatrix = append(model.ConstMatrix, Nonzero{i, j, v})
+		}
+	}
+	if sp, ok := A.(interface {
+		DoNonZero(fn func(i, j int, v float64))
+	}); ok {
+		sp.DoNonZero(add)
+		return model
+	}
+	nr, nc := A.Dims()
+	for i := 0; i < nr; i++ {
+		for j := 0; j < nc; j++ {
+			add(i, j, A.At(i, j))
+		}
+	}
+	return model
+}
+
+// SolveLP constructs and solves a linear-programming model in a single call.
+// The model optimizes costsᵀx subject to rowLower ≤ matrix·x ≤ rowUpper and
+// colLower ≤ x ≤ colUpper, where matrix is specified densely as a slice of
+// rows.  Any of the bound slices may be nil to select ToRawModel's defaults.
+// SolveLP is a convenience function for users who do not need the
+// flexibility of Model or RawModel.
+func SolveLP(costs []float64, matrix [][]float64, rowLower, rowUpper, colLower, colUpper []float64, maximize bool) (*RawSolution, error) {
+	// Construct the model.
+	model := Model{
+		Maximize: maximize,
+		ColCosts: costs,
+		ColLower: colLower,
+		ColUpper: colUpper,
+		RowLower: rowLower,
+		RowUpper: rowUpper,
+	}
+	for r, row := range matrix {
+		if len(row) > len(costs) {
+			return nil, fmt.Errorf("row %d has %d coefficients but there are only %d costs",
+				r, len(row), len(costs))
+		}
+		for c, v := range row {
+			if v != 0.0 {
+				model.ConstMatrix = append(model.ConstMatrix, Nonzero{r, c, v})
+			}
+		}
+	}
+
+	// Solve the model.
+	raw, err := model.ToRawModel()
+	if err != nil {
+		return nil, err
+	}
+	return raw.Solve()
+}
+
+// A BatchResult holds the outcome of solving one model in a batch.
+type BatchResult struct {
+	Solution *RawSolution // Solution to the model (nil if it could not be constructed)
+	Err      error        // Error returned by ToRawModel or Solve or for a nil model
+}
+
+// BatchSolveMap solves a set of models, keyed by caller-provided identifiers,
+// using up to concurrency goroutines at once (or one goroutine per CPU if
+// concurrency is not positive).  It returns a BatchResult for each model
+// under the same key.
+func BatchSolveMap(models map[string]*Model, concurrency int) map[string]BatchResult {
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+
+	// Launch a set of workers that solve models until none remain.
+	type job struct {
+		id    string
+		model *Model
+	}
+	jobs := make(chan job)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]BatchResult, len(models))
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				var res BatchResult
+				if j.model == nil {
+					res.Err = fmt.Errorf("model %q is nil", j.id)
+				} else if raw, err := j.model.ToRawModel(); err != nil {
+					res.Err = err
+				} else {
+					res.Solution, res.Err = raw.Solve()
+				}
+				mu.Lock()
+				results[j.id] = res
+				mu.Unlock()
+			}
+		}()
+	}
+
+	// Feed the workers every model then wait for them to finish.
+	for id, model := range models {
+		jobs <- job{id, model}
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+// An Arc represents a directed arc in a flow network.
+type Arc struct {
+	From     int     // Node at which the arc begins
+	To       int     // Node at which the arc ends
+	Cost     float64 // Cost per unit of flow along the arc
+	Capacity float64 // Maximum flow along the arc (math.Inf(1) if uncapacitated)
+}
+
+// NewMinCostFlowModel constructs a model of a minimum-cost network-flow
+// problem.  The model contains one column per arc, representing the flow
+// along that arc, and one equality row per node, requiring that the flow out
+// of the node minus the flow into the node equal the node's supply.  Demand
+// nodes have negative supply.  Total supply must equal total demand.
+func NewMinCostFlowModel(nodes int, arcs []Arc, supply []float64) (*Model, error) {
+	// Validate the arguments.
+	if len(supply) != nodes {
+		return nil, fmt.Errorf("expected %d supply values but received %d", nodes, len(supply))
+	}
+	total := 0.0
+	for _, s := range supply {
+		total += s
+	}
+	if math.Abs(total) > boundTol {
+		return nil, fmt.Errorf("total supply exceeds total demand by %v", total)
+	}
+
+	// Construct the model.
+	model := &Model{
+		ColCosts:    make([]float64, len(arcs)),
+		ColLower:    make([]float64, len(arcs)),
+		ColUpper:    make([]float64, len(arcs)),
+		RowLower:    cloneSlice(supply),
+		RowUpper:    cloneSlice(supply),
+		ConstMatrix: make([]Nonzero, 0, 2*len(arcs)),
+	}
+	for a, arc := range arcs {
+		switch {
+		case arc.From < 0 || arc.From >= nodes || arc.To < 0 || arc.To >= nodes:
+			return nil, fmt.Errorf("arc %d (%d→%d) refers to a nonexistent node", a, arc.From, arc.To)
+		case arc.From == arc.To:
+			return nil, fmt.Errorf("arc %d is a self-loop on node %d", a, arc.From)
+		case arc.Capacity < 0.0 || math.IsNaN(arc.Capacity):
+			return nil, fmt.Errorf("arc %d has invalid capacity %v", a, arc.Capacity)
+		}
+		model.ColCosts[a] = arc.Cost
+		model.ColUpper[a] = arc.Capacity
+		model.ConstMatrix = append(model.ConstMatrix,
+			Nonzero{arc.From, a, 1.0},
+			Nonzero{arc.To, a, -1.0})
+	}
+	return model, nil
+}
+
 // modelSize returns the number of rows and columns in a model.  It works by
 // taking the maximum encountered in any of the fields representing rows or
 // columns.
@@ -87,8 +325,86 @@ func (m *Model) modelSize() (int, int) {
 	return nr, nc
 }
 
-// ToRawModel converts a high-level model to a low-level model.
+// denseVectors returns a model's column costs, column bounds, and row bounds,
+// each expanded to its full length using the same defaults as ToRawModel.
+func (m *Model) denseVectors() (cost, colLower, colUpper, rowLower, rowUpper []float64, err error) {
+	nr, nc := m.modelSize()
+	var ok bool
+	mInf, pInf := -Infinity(), Infinity()
+	if cost, ok = expandToLen(nc, m.ColCosts, 1.0); !ok {
+		return nil, nil, nil, nil, nil, fmt.Errorf("inconsistent column counts")
+	}
+	if colLower, ok = expandToLen(nc, m.ColLower, mInf); !ok {
+		return nil, nil, nil, nil, nil, fmt.Errorf("inconsistent column counts")
+	}
+	if colUpper, ok = expandToLen(nc, m.ColUpper, pInf); !ok {
+		return nil, nil, nil, nil, nil, fmt.Errorf("inconsistent column counts")
+	}
+	if rowLower, ok = expandToLen(nr, m.RowLower, mInf); !ok {
+		return nil, nil, nil, nil, nil, fmt.Errorf("inconsistent row counts")
+	}
+	if rowUpper, ok = expandToLen(nr, m.RowUpper, pInf); !ok {
+		return nil, nil, nil, nil, nil, fmt.Errorf("inconsistent row counts")
+	}
+	return cost, colLower, colUpper, rowLower, rowUpper, nil
+}
+
+// colName returns the name of a column: its entry in ColNames if non-empty or
+// otherwise "c" followed by the column number, as in HiGHS's own output.
+func (m *Model) colName(c int) string {
+	if c < len(m.ColNames) && m.ColNames[c] != "" {
+		return m.ColNames[c]
+	}
+	return fmt.Sprintf("c%d", c)
+}
+
+// ToRawModel converts a high-level model to a low-level model.  If ColScale
+// or RowScale is specified, the low-level model is expressed in scaled units:
+// each column value is divided by its column's scale factor, and each row is
+// multiplied by its row's scale factor.
 func (m *Model) ToRawModel() (*RawModel, error) {
+	return m.ToRawModelContext(context.Background())
+}
+
+// ToRawModelContext is a variant of ToRawModel that aborts the conversion if
+// ctx is cancelled.  Cancellation is checked periodically while the model's
+// sparse matrices are assembled and again after the model is passed to HiGHS.
+// If the conversion is aborted, ToRawModelContext frees the partially
+// constructed low-level model and returns ctx.Err().
+func (m *Model) ToRawModelContext(ctx context.Context) (*RawModel, error) {
+	return m.toRawModel(ctx, nil)
+}
+
+// ToRawModelWithProgress is a variant of ToRawModel that periodically reports
+// its progress, as a fraction from 0 to 1, for the benefit of user interfaces
+// that convert very large models.  Most of the range is devoted to assembling
+// the model's sparse matrices.  The final report, of 1, is made once the
+// conversion succeeds.
+func (m *Model) ToRawModelWithProgress(fn func(fraction float64)) (*RawModel, error) {
+	return m.toRawModel(context.Background(), fn)
+}
+
+// toRawModel implements ToRawModelContext and ToRawModelWithProgress.
+// progress may be nil.
+func (m *Model) toRawModel(ctx context.Context, progress func(float64)) (*RawModel, error) {
+	// Map each phase of the conversion to a subrange of [0, 1].
+	phase := func(lo, hi float64) func(float64) {
+		if progress == nil {
+			return nil
+		}
+		return func(f float64) { progress(lo + f*(hi-lo)) }
+	}
+
+	// Convert ConstMatrix and HessianMatrix to CSR format.
+	aStart, aIndex, aValue, err := nonzerosToCSRContext(ctx, m.ConstMatrix, false, phase(0.0, 0.8))
+	if err != nil {
+		return &RawModel{}, err
+	}
+	qStart, qIndex, qValue, err := nonzerosToCSRContext(ctx, m.HessianMatrix, true, phase(0.8, 0.9))
+	if err != nil {
+		return &RawModel{}, err
+	}
+
 	// Construct an empty raw model.  Turn off output, which is out of
 	// place in a method like ToRawModel.
 	raw := NewRawModel()
@@ -101,14 +417,16 @@ func (m *Model) ToRawModel() (*RawModel, error) {
 		return &RawModel{}, err
 	}
 
-	// Convert ConstMatrix and HessianMatrix to CSR format.
-	aStart, aIndex, aValue, err := nonzerosToCSR(m.ConstMatrix, false)
-	if err != nil {
-		return &RawModel{}, err
+	// HiGHS converts bounds to infinity when the model is passed in, so
+	// the infinity threshold must be set first.
+	if m.InfinityBound < 0.0 || math.IsNaN(m.InfinityBound) {
+		return &RawModel{}, fmt.Errorf("InfinityBound must be positive but is %v", m.InfinityBound)
 	}
-	qStart, qIndex, qValue, err := nonzerosToCSR(m.HessianMatrix, true)
-	if err != nil {
-		return &RawModel{}, err
+	if m.InfinityBound != 0.0 {
+		err = raw.SetFloat64Option("infinite_bound", m.InfinityBound)
+		if err != nil {
+			return &RawModel{}, err
+		}
 	}
 
 	// Convert Go values to C values.
@@ -139,7 +457,7 @@ func (m *Model) ToRawModel() (*RawModel, error) {
 	if colCost, ok = expandToLen(nc, colCost, 1.0); !ok {
 		return &RawModel{}, fmt.Errorf("inconsistent column counts")
 	}
-	mInf, pInf := C.double(math.Inf(-1)), C.double(math.Inf(1))
+	mInf, pInf := C.double(-Infinity()), C.double(Infinity())
 	if colLower, ok = expandToLen(nc, colLower, mInf); !ok {
 		return &RawModel{}, fmt.Errorf("inconsistent column counts")
 	}
@@ -166,7 +484,37 @@ func (m *Model) ToRawModel() (*RawModel, error) {
 		sliceToPointer(aStart), sliceToPointer(aIndex), sliceToPointer(aValue),
 		sliceToPointer(qStart), sliceToPointer(qIndex), sliceToPointer(qValue),
 		sliceToPointer(integrality))
-	err = newCallStatus(status, "Highs_passModel", "ToRawModel")
+	err = raw.recordStatus(status, "Highs_passModel", "ToRawModel")
+	if err != nil {
+		return &RawModel{}, err
+	}
+	if err = ctx.Err(); err != nil {
+		raw.free()
+		return &RawModel{}, err
+	}
+	if progress != nil {
+		progress(0.95)
+	}
+
+	// Name the columns, if names were provided.
+	if len(m.ColNames) != 0 && len(m.ColNames) != nc {
+		return &RawModel{}, fmt.Errorf("ColNames must be empty or contain %d elements", nc)
+	}
+	for c, name := range m.ColNames {
+		err = raw.passColName(c, name, "ToRawModel")
+		if err != nil {
+			return &RawModel{}, err
+		}
+	}
+
+	// Scale columns and rows as requested.
+	err = m.applyScaling(raw, nr, nc)
+	if err != nil {
+		return &RawModel{}, err
+	}
+
+	// Apply any solver options specified by the model.
+	err = m.applyOptions(raw)
 	if err != nil {
 		return &RawModel{}, err
 	}
@@ -176,9 +524,146 @@ func (m *Model) ToRawModel() (*RawModel, error) {
 	if err != nil {
 		return &RawModel{}, err
 	}
+	if progress != nil {
+		progress(1.0)
+	}
 	return raw, nil
 }
 
+// applyScaling validates the ColScale and RowScale fields of a high-level
+// model and scales the corresponding columns and rows of a low-level model.
+func (m *Model) applyScaling(raw *RawModel, nr, nc int) error {
+	if len(m.ColScale) != 0 && len(m.ColScale) != nc {
+		return fmt.Errorf("ColScale must be empty or contain %d elements", nc)
+	}
+	if len(m.RowScale) != 0 && len(m.RowScale) != nr {
+		return fmt.Errorf("RowScale must be empty or contain %d elements", nr)
+	}
+	for c, s := range m.ColScale {
+		if s == 0.0 {
+			continue
+		}
+		if math.IsNaN(s) || math.IsInf(s, 0) {
+			return fmt.Errorf("column %d has an invalid scale factor (%v)", c, s)
+		}
+		status := C.Highs_scaleCol(raw.obj, C.HighsInt(c), C.double(s))
+		err := raw.recordStatus(status, "Highs_scaleCol", "ToRawModel")
+		if err != nil {
+			return err
+		}
+	}
+	for r, s := range m.RowScale {
+		if s == 0.0 {
+			continue
+		}
+		if math.IsNaN(s) || math.IsInf(s, 0) {
+			return fmt.Errorf("row %d has an invalid scale factor (%v)", r, s)
+		}
+		status := C.Highs_scaleRow(raw.obj, C.HighsInt(r), C.double(s))
+		err := raw.recordStatus(status, "Highs_scaleRow", "ToRawModel")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unscaleSolution maps a solution of a model scaled by applyScaling back to
+// the model's original units.
+func (m *Model) unscaleSolution(soln *Solution) {
+	for c, s := range m.ColScale {
+		if s == 0.0 {
+			continue
+		}
+		if c < len(soln.ColumnPrimal) {
+			soln.ColumnPrimal[c] *= s
+		}
+		if c < len(soln.ColumnDual) {
+			soln.ColumnDual[c] /= s
+		}
+	}
+	for r, s := range m.RowScale {
+		if s == 0.0 {
+			continue
+		}
+		if r < len(soln.RowPrimal) {
+			soln.RowPrimal[r] /= s
+		}
+		if r < len(soln.RowDual) {
+			soln.RowDual[r] *= s
+		}
+	}
+}
+
+// applyOptions validates the option fields of a high-level model and assigns
+// those with nonzero values to a low-level model.
+func (m *Model) applyOptions(raw *RawModel) error {
+	if m.MIPHeuristicEffort != nil {
+		effort := *m.MIPHeuristicEffort
+		if !(effort >= 0.0 && effort <= 1.0) {
+			return fmt.Errorf("MIPHeuristicEffort must lie in [0, 1] but is %v", effort)
+		}
+		err := raw.SetFloat64Option("mip_heuristic_effort", effort)
+		if err != nil {
+			return err
+		}
+	}
+	if m.SimplexCrash < SimplexCrashOff || m.SimplexCrash > SimplexCrashTestSing {
+		return fmt.Errorf("invalid SimplexCrash value (%d)", m.SimplexCrash)
+	}
+	if m.SimplexCrash != SimplexCrashOff {
+		err := raw.SetIntOption("simplex_crash_strategy", int(m.SimplexCrash))
+		if err != nil {
+			return err
+		}
+	}
+	if m.SimplexDualEdgeWeight < SimplexDualEdgeWeightChoose || m.SimplexDualEdgeWeight > SimplexDualEdgeWeightSteepestEdge {
+		return fmt.Errorf("invalid SimplexDualEdgeWeight value (%d)", m.SimplexDualEdgeWeight)
+	}
+	if m.SimplexDualEdgeWeight != SimplexDualEdgeWeightChoose {
+		// HiGHS numbers its strategies from -1 (choose).
+		err := raw.SetIntOption("simplex_dual_edge_weight_strategy", int(m.SimplexDualEdgeWeight)-1)
+		if err != nil {
+			return err
+		}
+	}
+	if m.PresolveReductionLimit != nil {
+		if *m.PresolveReductionLimit < 0 {
+			return fmt.Errorf("PresolveReductionLimit must be nonnegative but is %d", *m.PresolveReductionLimit)
+		}
+		err := raw.SetIntOption("presolve_reduction_limit", *m.PresolveReductionLimit)
+		if err != nil {
+			return err
+		}
+	}
+	if m.PresolveMaxFillIn != nil {
+		if *m.PresolveMaxFillIn < 0 {
+			return fmt.Errorf("PresolveMaxFillIn must be nonnegative but is %d", *m.PresolveMaxFillIn)
+		}
+		err := raw.SetIntOption("presolve_substitution_maxfillin", *m.PresolveMaxFillIn)
+		if err != nil {
+			return err
+		}
+	}
+	if len(m.ImpliedFreeColumns) > 0 {
+		// HiGHS's presolve detects implied-free columns itself and
+		// accepts no hints.
+		return fmt.Errorf("hinting implied-free columns: %w", ErrUnsupported)
+	}
+	if m.IterationLimit < 0 {
+		return fmt.Errorf("IterationLimit must be nonnegative but is %d", m.IterationLimit)
+	}
+	if m.IterationLimit != 0 {
+		for _, opt := range []string{"simplex_iteration_limit", "ipm_iteration_limit"} {
+			err := raw.SetIntOption(opt, m.IterationLimit)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // A Solution encapsulates all the values returned by any of HiGHS's solvers.
 // Not all fields will be meaningful when returned by any given solver.
 type Solution struct {
@@ -192,8 +677,25 @@ type Solution struct {
 	Objective    float64       // Objective value
 }
 
+// ReducedCosts returns the reduced cost of each column: the rate at which the
+// objective value would change if the column's value were forced away from
+// its current value.  Basic columns have a reduced cost of zero.
+// ReducedCosts returns the ColumnDual field, not a copy.
+func (s *Solution) ReducedCosts() []float64 {
+	return s.ColumnDual
+}
+
+// ShadowPrices returns the shadow price of each row: the rate at which the
+// objective value would change if the row's binding bound (right-hand side)
+// were relaxed or tightened.  Rows whose bounds are not binding have a shadow
+// price of zero.  ShadowPrices returns the RowDual field, not a copy.
+func (s *Solution) ShadowPrices() []float64 {
+	return s.RowDual
+}
+
 // Solve solves the model as either an LP, MIP, or QP problem, depending on
-// which fields are non-nil.
+// which fields are non-nil.  As with RawModel.Solve, a partial solution is
+// returned along with any warning.
 func (m *Model) Solve() (Solution, error) {
 	// Convert the Model to a RawModel.
 	var cs CallStatus
@@ -217,10 +719,486 @@ func (m *Model) Solve() (Solution, error) {
 		return Solution{}, err
 	}
 
-	// Solve the raw model.
+	// Solve the raw model and express the solution in unscaled units.
+	// Return partial solutions along with any warnings.
 	soln, err := raw.Solve()
-	if err != nil {
+	if err != nil && !(errors.As(err, &cs) && cs.IsWarning()) {
 		return Solution{}, err
 	}
-	return soln.Solution, nil
+	m.unscaleSolution(&soln.Solution)
+	return soln.Solution, err
+}
+
+// ApplySolution stores a copy of a solution's primal column values in the
+// model's Solution field so the model can carry its most recent answer
+// through later processing.  The solution is expected to come from the raw
+// model produced by m.ToRawModel, so ApplySolution expresses the values in
+// unscaled units.
+func (m *Model) ApplySolution(s *RawSolution) {
+	m.Solution = append([]float64(nil), s.ColumnPrimal...)
+	for c, sc := range m.ColScale {
+		if sc != 0.0 && c < len(m.Solution) {
+			m.Solution[c] *= sc
+		}
+	}
+}
+
+// SolveVerified is a variant of Solve that double-checks the solution HiGHS
+// returns.  It confirms, to within an absolute tolerance tol, that the
+// solution satisfies all of the model's bounds and integrality requirements.
+// It also confirms that the solution's objective value matches the value
+// recomputed from the column values to within a relative tolerance tol (or
+// an absolute tolerance tol for objective values smaller than 1 in
+// magnitude).  SolveVerified returns an error, and no solution, if HiGHS does
+// not report an optimal solution or if the solution fails verification.
+func (m *Model) SolveVerified(tol float64) (*RawSolution, error) {
+	if tol < 0.0 || math.IsNaN(tol) {
+		return nil, fmt.Errorf("tolerance must be nonnegative but is %v", tol)
+	}
+
+	// Solve the model, and express the solution in unscaled units.
+	raw, err := m.ToRawModel()
+	if err != nil {
+		return nil, err
+	}
+	soln, err := raw.Solve()
+	var cs CallStatus
+	if err != nil && !(errors.As(err, &cs) && cs.IsWarning()) {
+		return nil, err
+	}
+	m.unscaleSolution(&soln.Solution)
+
+	// Verify the solution.
+	if soln.Status != Optimal {
+		return nil, fmt.Errorf("solve ended with status %v", soln.Status)
+	}
+	vErr := soln.verify(m, tol)
+	if vErr != nil {
+		return nil, fmt.Errorf("solution failed verification: %w", vErr)
+	}
+	return soln, err
+}
+
+// SolveExactCheck solves a linear program, then uses exact rational
+// arithmetic to check whether the optimal basis HiGHS returned is truly
+// optimal.  It recomputes the basic solution and its duals from the basis and
+// the model's data, which are treated as exact, and reports true if the basic
+// solution satisfies every bound and the reduced costs and row duals all have
+// the signs that optimality requires.  A false result means that HiGHS's
+// floating-point solution is at best approximate.  SolveExactCheck returns an
+// error if the model is not a linear program, if ColScale or RowScale
+// contains a scale factor that is neither zero nor positive and finite, or if
+// HiGHS does not return an optimal basis.
+func (m *Model) SolveExactCheck() (*RawSolution, bool, error) {
+	// Solve the model.
+	if len(m.HessianMatrix) > 0 || !m.isContinuous() {
+		return nil, false, errors.New("SolveExactCheck requires a linear program")
+	}
+	err := m.checkPositiveScaling()
+	if err != nil {
+		return nil, false, err
+	}
+	raw, err := m.ToRawModel()
+	if err != nil {
+		return nil, false, err
+	}
+	soln, err := raw.Solve()
+	var cs CallStatus
+	if err != nil && !(errors.As(err, &cs) && cs.IsWarning()) {
+		return nil, false, err
+	}
+	m.unscaleSolution(&soln.Solution)
+	if soln.Status != Optimal {
+		return soln, false, soln.Err()
+	}
+
+	// Check the basis.
+	exact, eErr := m.isExactlyOptimal(soln.ColumnBasis, soln.RowBasis)
+	if eErr != nil {
+		return soln, false, eErr
+	}
+	return soln, exact, err
+}
+
+// checkPositiveScaling returns an error if a model's ColScale or RowScale
+// contains a scale factor that is neither zero (no scaling) nor positive and
+// finite.  A negative scale factor swaps a column's or row's bounds in the
+// low-level model and hence the meaning of its Lower and Upper basis
+// statuses.
+func (m *Model) checkPositiveScaling() error {
+	for c, s := range m.ColScale {
+		if s < 0.0 || math.IsNaN(s) || math.IsInf(s, 0) {
+			return fmt.Errorf("column %d has a scale factor (%v) that is not positive", c, s)
+		}
+	}
+	for r, s := range m.RowScale {
+		if s < 0.0 || math.IsNaN(s) || math.IsInf(s, 0) {
+			return fmt.Errorf("row %d has a scale factor (%v) that is not positive", r, s)
+		}
+	}
+	return nil
+}
+
+// isExactlyOptimal implements the rational-arithmetic check performed by
+// SolveExactCheck for a given basis.
+func (m *Model) isExactlyOptimal(colBasis, rowBasis []BasisStatus) (bool, error) {
+	err := m.checkPositiveScaling()
+	if err != nil {
+		return false, err
+	}
+	cost, colLower, colUpper, rowLower, rowUpper, err := m.denseVectors()
+	if err != nil {
+		return false, err
+	}
+	aNz, err := filterNonzeros(m.ConstMatrix, false)
+	if err != nil {
+		return false, err
+	}
+	nc, nr := len(cost), len(rowLower)
+	if len(colBasis) != nc || len(rowBasis) != nr {
+		return false, errors.New("no complete basis is available")
+	}
+
+	// Determine the value of each nonbasic column and the activity of each
+	// nonbasic row.
+	nonbasicValue := func(bs BasisStatus, lower, upper float64) (*big.Rat, bool) {
+		var v float64
+		switch bs {
+		case Lower:
+			v = lower
+		case Upper:
+			v = upper
+		case Zero:
+			return new(big.Rat), true
+		default:
+			return nil, false
+		}
+		if math.Abs(v) >= infiniteBound {
+			return nil, false
+		}
+		return new(big.Rat).SetFloat64(v), true
+	}
+	x := make([]*big.Rat, nc)
+	basicPos := make(map[int]int) // Map from a basic column to its position
+	var basicCols []int
+	for c, bs := range colBasis {
+		if bs == Basic {
+			basicPos[c] = len(basicCols)
+			basicCols = append(basicCols, c)
+			continue
+		}
+		var ok bool
+		if x[c], ok = nonbasicValue(bs, colLower[c], colUpper[c]); !ok {
+			return false, nil
+		}
+	}
+	nonbasicPos := make(map[int]int) // Map from a nonbasic row to its position
+	var nonbasicRows []int
+	var rhs []*big.Rat
+	for r, bs := range rowBasis {
+		if bs == Basic {
+			continue
+		}
+		v, ok := nonbasicValue(bs, rowLower[r], rowUpper[r])
+		if !ok {
+			return false, nil
+		}
+		nonbasicPos[r] = len(nonbasicRows)
+		nonbasicRows = append(nonbasicRows, r)
+		rhs = append(rhs, v)
+	}
+	n := len(basicCols)
+	if len(nonbasicRows) != n {
+		return false, nil
+	}
+
+	// Solve B x_B = b − N x_N for the basic columns, where B comprises the
+	// basic columns' coefficients in the nonbasic rows.
+	newMatrix := func() [][]*big.Rat {
+		mat := make([][]*big.Rat, n)
+		for i := range mat {
+			mat[i] = make([]*big.Rat, n)
+			for j := range mat[i] {
+				mat[i][j] = new(big.Rat)
+			}
+		}
+		return mat
+	}
+	bMat, btMat := newMatrix(), newMatrix()
+	tmp := new(big.Rat)
+	for _, nz := range aNz {
+		k, ok := nonbasicPos[nz.Row]
+		if !ok {
+			continue
+		}
+		v := new(big.Rat).SetFloat64(nz.Val)
+		if p, ok := basicPos[nz.Col]; ok {
+			bMat[k][p].Add(bMat[k][p], v)
+			btMat[p][k].Add(btMat[p][k], v)
+		} else {
+			rhs[k].Sub(rhs[k], tmp.Mul(v, x[nz.Col]))
+		}
+	}
+	xB, ok := solveRational(bMat, rhs)
+	if !ok {
+		return false, nil
+	}
+	for p, c := range basicCols {
+		x[c] = xB[p]
+	}
+
+	// Check primal feasibility.
+	inBounds := func(v *big.Rat, lower, upper float64) bool {
+		if lower > -infiniteBound && v.Cmp(new(big.Rat).SetFloat64(lower)) < 0 {
+			return false
+		}
+		if upper < infiniteBound && v.Cmp(new(big.Rat).SetFloat64(upper)) > 0 {
+			return false
+		}
+		return true
+	}
+	activity := make([]*big.Rat, nr)
+	for r := range activity {
+		activity[r] = new(big.Rat)
+	}
+	for _, nz := range aNz {
+		v := new(big.Rat).SetFloat64(nz.Val)
+		activity[nz.Row].Add(activity[nz.Row], v.Mul(v, x[nz.Col]))
+	}
+	for c, v := range x {
+		if !inBounds(v, colLower[c], colUpper[c]) {
+			return false, nil
+		}
+	}
+	for r, v := range activity {
+		if !inBounds(v, rowLower[r], rowUpper[r]) {
+			return false, nil
+		}
+	}
+
+	// Solve Bᵀy = c_B for the nonbasic rows' duals.  Negate the costs of
+	// a maximization problem to express it as a minimization problem.
+	sense := new(big.Rat).SetInt64(1)
+	if m.Maximize {
+		sense.SetInt64(-1)
+	}
+	cB := make([]*big.Rat, n)
+	for p, c := range basicCols {
+		cB[p] = new(big.Rat).SetFloat64(cost[c])
+		cB[p].Mul(cB[p], sense)
+	}
+	yN, ok := solveRational(btMat, cB)
+	if !ok {
+		return false, nil
+	}
+
+	// Check dual feasibility.  A nonbasic column or row at its lower bound
+	// must have a nonnegative dual value, one at its upper bound must have
+	// a nonpositive dual value, and one that is free must have a zero
+	// dual value.  Fixed columns and rows may have any dual value.
+	dualOK := func(bs BasisStatus, d *big.Rat, lower, upper float64) bool {
+		switch {
+		case lower == upper:
+			return true
+		case bs == Lower:
+			return d.Sign() >= 0
+		case bs == Upper:
+			return d.Sign() <= 0
+		default:
+			return d.Sign() == 0
+		}
+	}
+	z := make([]*big.Rat, nc)
+	for c := range z {
+		z[c] = new(big.Rat).SetFloat64(cost[c])
+		z[c].Mul(z[c], sense)
+	}
+	for _, nz := range aNz {
+		if k, ok := nonbasicPos[nz.Row]; ok {
+			v := new(big.Rat).SetFloat64(nz.Val)
+			z[nz.Col].Sub(z[nz.Col], v.Mul(v, yN[k]))
+		}
+	}
+	for c, bs := range colBasis {
+		if bs != Basic && !dualOK(bs, z[c], colLower[c], colUpper[c]) {
+			return false, nil
+		}
+	}
+	for k, r := range nonbasicRows {
+		if !dualOK(rowBasis[r], yN[k], rowLower[r], rowUpper[r]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// SolveTimed is a variant of RawModel.SolveTimed that solves a high-level
+// model and additionally reports the time taken to construct the
+// corresponding low-level model.  Solution values are expressed in the
+// model's original units, and the time taken to unscale them is included in
+// Extract.
+func (m *Model) SolveTimed() (*RawSolution, SolveTiming, error) {
+	begin := time.Now()
+	raw, err := m.ToRawModel()
+	build := time.Since(begin)
+	if err != nil {
+		return nil, SolveTiming{Build: build, Total: build}, err
+	}
+	soln, timing, err := raw.SolveTimed()
+	timing.Build = build
+	unscaleBegin := time.Now()
+	m.unscaleSolution(&soln.Solution)
+	timing.Extract += time.Since(unscaleBegin)
+	timing.Total = time.Since(begin)
+	return soln, timing, err
+}
+
+// SolveWithModelDump solves the model as either an LP, MIP, or QP problem,
+// depending on which fields are non-nil.  It additionally returns the MPS
+// representation of exactly the low-level model that was solved, which can
+// help reproduce unexpected results.  If the model specifies ColScale or
+// RowScale, the dump represents the scaled model, but the solution is
+// expressed in the model's original units.
+func (m *Model) SolveWithModelDump() (*RawSolution, string, error) {
+	// Convert the Model to a RawModel.
+	raw, err := m.ToRawModel()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Capture the model in MPS format.  Ignore warnings.
+	var buf bytes.Buffer
+	err = raw.WriteModel(&buf)
+	var cs CallStatus
+	if err != nil && !(errors.As(err, &cs) && cs.IsWarning()) {
+		return nil, "", err
+	}
+	dump := buf.String()
+
+	// Solve the raw model and express the solution in unscaled units.
+	// Return partial solutions along with any warnings.
+	soln, err := raw.Solve()
+	if err != nil && !(errors.As(err, &cs) && cs.IsWarning()) {
+		return nil, dump, err
+	}
+	m.unscaleSolution(&soln.Solution)
+	return soln, dump, err
+}
+
+// EnumerateFeasible returns up to maxSolutions feasible points of a binary
+// program, in order of nondecreasing (or, for maximization problems,
+// nonincreasing) objective value.  A maxSolutions of zero or less imposes no
+// limit.  EnumerateFeasible solves the model repeatedly, each time adding a
+// "no-good" row that excludes the point just found, until the model becomes
+// infeasible.  This is practical only for tiny models.  Every column must be an
+// integer column with bounds within [0, 1], and because a scaled column would no
+// longer be binary, ColScale must not contain any nonzero elements.
+func (m *Model) EnumerateFeasible(maxSolutions int) ([][]float64, error) {
+	// Ensure we were given a binary program.
+	_, colLower, colUpper, _, _, err := m.denseVectors()
+	if err != nil {
+		return nil, err
+	}
+	for c, s := range m.ColScale {
+		if s != 0.0 {
+			return nil, fmt.Errorf("column %d is scaled, so it is not binary", c)
+		}
+	}
+	nc := len(colLower)
+	for c := 0; c < nc; c++ {
+		if c >= len(m.VarTypes) || m.VarTypes[c] != IntegerType ||
+			colLower[c] < 0.0 || colUpper[c] > 1.0 {
+			return nil, fmt.Errorf("column %d is not binary", c)
+		}
+	}
+
+	// Repeatedly solve the model and exclude the solution found.
+	raw, err := m.ToRawModel()
+	if err != nil {
+		return nil, err
+	}
+	var points [][]float64
+	cols := make([]int, nc)
+	for c := range cols {
+		cols[c] = c
+	}
+	for maxSolutions <= 0 || len(points) < maxSolutions {
+		soln, err := raw.Solve()
+		if err != nil {
+			return nil, err
+		}
+		switch soln.Status {
+		case Optimal:
+		case Infeasible:
+			return points, nil
+		default:
+			return nil, fmt.Errorf("enumeration stopped with status %s", soln.Status)
+		}
+		m.unscaleSolution(&soln.Solution)
+		x := soln.RoundedColumns(0.5)
+		points = append(points, x)
+
+		// Exclude x with the row Σ_{x_j=0} x_j + Σ_{x_j=1} (1 - x_j) ≥ 1.
+		coeffs := make([]float64, nc)
+		lower := 1.0
+		for c, v := range x {
+			if v == 0.0 {
+				coeffs[c] = 1.0
+			} else {
+				coeffs[c] = -1.0
+				lower--
+			}
+		}
+		err = raw.AddRow(lower, math.Inf(1), cols, coeffs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return points, nil
+}
+
+// ParametricObjective traces the trade-off between two objective functions.
+// For each λ in lambdas, it solves the model with column costs (1−λ)·c1 +
+// λ·c2, ignoring ColCosts.  All solves share a single low-level model, so each
+// solve after the first is warm-started from the previous one's basis.  The
+// returned solutions are expressed in unscaled units.
+func (m *Model) ParametricObjective(c1, c2 []float64, lambdas []float64) ([]*RawSolution, error) {
+	// Check for simple errors.
+	_, nc := m.modelSize()
+	if len(c1) != nc || len(c2) != nc {
+		return nil, fmt.Errorf("c1 and c2 must both contain %d elements (%d and %d)",
+			nc, len(c1), len(c2))
+	}
+
+	// Solve the model once per λ.
+	raw, err := m.ToRawModel()
+	if err != nil {
+		return nil, err
+	}
+	cols := make([]int, nc)
+	for c := range cols {
+		cols[c] = c
+	}
+	costs := make([]float64, nc)
+	solns := make([]*RawSolution, 0, len(lambdas))
+	for _, lambda := range lambdas {
+		for c := range costs {
+			costs[c] = (1.0-lambda)*c1[c] + lambda*c2[c]
+			if c < len(m.ColScale) && m.ColScale[c] != 0.0 {
+				costs[c] *= m.ColScale[c]
+			}
+		}
+		err = raw.ChangeColumnCosts(cols, costs)
+		if err != nil {
+			return nil, err
+		}
+		soln, err := raw.Solve()
+		var cs CallStatus
+		if err != nil && !(errors.As(err, &cs) && cs.IsWarning()) {
+			return nil, err
+		}
+		m.unscaleSolution(&soln.Solution)
+		solns = append(solns, soln)
+	}
+	return solns, nil
 }
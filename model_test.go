@@ -6,8 +6,14 @@ package highs
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"math"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 // TestMakeSparseMatrix tests the conversion of a slice of Nonzeros to start,
@@ -103,6 +109,120 @@ func TestReadModelFromFile(t *testing.T) {
 	}
 }
 
+// TestReadModelFromFileFormats tests that ReadModelFromFile reads models in
+// both MPS and LP format, selecting the format by filename extension, and
+// that it reports an error for a nonexistent file or an unrecognized
+// extension.  Both files
+// represent the following model:
+//
+//	Min. 2*x + y
+//	s.t. 3 <= x + y
+//	0 <= x, 0 <= y
+func TestReadModelFromFileFormats(t *testing.T) {
+	files := map[string]string{
+		"tiny.mps": `NAME tiny
+ROWS
+ N  obj
+ G  c1
+COLUMNS
+    x  obj  2  c1  1
+    y  obj  1  c1  1
+RHS
+    rhs  c1  3
+ENDATA
+`,
+		"tiny.lp": `Minimize
+ obj: 2 x + y
+Subject To
+ c1: x + y >= 3
+End
+`,
+	}
+	dir := t.TempDir()
+	for name, contents := range files {
+		// Write the model to a file.
+		fname := filepath.Join(dir, name)
+		err := os.WriteFile(fname, []byte(contents), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Read the model back and solve it.
+		model := NewRawModel()
+		checkErr(t, model.SetBoolOption("output_flag", false))
+		checkErr(t, model.ReadModelFromFile(fname))
+		soln, err := model.Solve()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if soln.Status != Optimal || soln.Objective != 3.0 {
+			t.Fatalf("%s: expected an optimal objective of 3 but saw %v (%s)",
+				name, soln.Objective, soln.Status)
+		}
+	}
+
+	// Ensure that a missing file is reported as a HiGHS error.
+	model := NewRawModel()
+	checkErr(t, model.SetBoolOption("output_flag", false))
+	err := model.ReadModelFromFile(filepath.Join(dir, "missing.mps"))
+	var cs CallStatus
+	if !errors.As(err, &cs) {
+		t.Fatalf("expected a CallStatus error but received %v", err)
+	}
+
+	// Ensure that an unrecognized extension is rejected.
+	fname := filepath.Join(dir, "tiny.txt")
+	err = os.WriteFile(fname, []byte(files["tiny.mps"]), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = model.ReadModelFromFile(fname)
+	if err == nil {
+		t.Fatal("expected ReadModelFromFile to reject a .txt file")
+	}
+}
+
+// TestWriteModelToFileFormats tests that models written by WriteModelToFile in
+// each supported format can be read back in.  A file with an unrecognized
+// extension should be written in MPS format.
+func TestWriteModelToFileFormats(t *testing.T) {
+	model := minimalAPIModel(false)
+	raw := mustToRawModel(t, model)
+	dir := t.TempDir()
+	for _, name := range []string{"model.mps", "model.lp", "model.ems", "model.mps.gz", "model.txt"} {
+		// Write the model to a file.
+		fname := filepath.Join(dir, name)
+		checkErr(t, raw.WriteModelToFile(fname))
+
+		// Read the model back.  Read unrecognized extensions as MPS.
+		var err error
+		m2 := NewRawModel()
+		checkErr(t, m2.SetBoolOption("output_flag", false))
+		if filepath.Ext(name) == ".txt" {
+			var f *os.File
+			f, err = os.Open(fname)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = m2.ReadModel(f)
+			f.Close()
+		} else {
+			err = m2.ReadModelFromFile(fname)
+		}
+		checkErr(t, err)
+
+		// Confirm that the model's dimensions survived the round trip.
+		back, err := m2.toModel()
+		if err != nil {
+			t.Fatal(err)
+		}
+		nr, nc := back.modelSize()
+		if nr != 3 || nc != 2 {
+			t.Fatalf("%s: expected 3 rows and 2 columns but saw %d and %d", name, nr, nc)
+		}
+	}
+}
+
 // TestReadWriteModel tests writing a model to a buffer then reading it back in
 // and solving it.  It uses the same model as
 // TestWriteModelToFile/TestReadModelFromFile.
@@ -126,3 +246,492 @@ func TestReadWriteModel(t *testing.T) {
 	checkErr(t, m2.SetBoolOption("output_flag", false))
 	checkErr(t, m2.ReadModel(&buf))
 }
+
+// TestMIPHeuristicEffort tests that a model's MIPHeuristicEffort, including an
+// effort of zero, is passed to HiGHS and that out-of-range values are
+// rejected.
+func TestMIPHeuristicEffort(t *testing.T) {
+	// Set valid heuristic efforts and read them back.
+	var model Model
+	model.AddDenseRow(1.0, []float64{1.0, 1.0}, 2.0)
+	for _, want := range []float64{0.25, 0.0} {
+		effort := want
+		model.MIPHeuristicEffort = &effort
+		raw, err := model.ToRawModel()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := raw.GetFloat64Option("mip_heuristic_effort")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("expected a MIP heuristic effort of %v but saw %v", want, got)
+		}
+	}
+
+	// Ensure that an invalid heuristic effort is rejected.
+	effort := 1.5
+	model.MIPHeuristicEffort = &effort
+	_, err := model.ToRawModel()
+	if err == nil {
+		t.Fatal("expected ToRawModel to reject a MIP heuristic effort of 1.5")
+	}
+}
+
+// TestSimplexCrash tests that a model's SimplexCrash is passed to HiGHS as
+// the corresponding simplex_crash_strategy value and that invalid values are
+// rejected.
+func TestSimplexCrash(t *testing.T) {
+	// Set each of a few crash strategies and read it back.
+	var model Model
+	model.AddDenseRow(1.0, []float64{1.0, 1.0}, 2.0)
+	for _, tc := range []struct {
+		crash SimplexCrash
+		highs int // HiGHS's value for the strategy
+	}{
+		{SimplexCrashBixby, 2},
+		{SimplexCrashLTSFK, 4},
+		{SimplexCrashLTSF, 6},
+		{SimplexCrashBasic, 8},
+	} {
+		model.SimplexCrash = tc.crash
+		raw, err := model.ToRawModel()
+		if err != nil {
+			t.Fatal(err)
+		}
+		crash, err := raw.GetIntOption("simplex_crash_strategy")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if crash != tc.highs {
+			t.Fatalf("expected %v to set a crash strategy of %d but saw %d",
+				tc.crash, tc.highs, crash)
+		}
+	}
+
+	// Ensure that an invalid crash strategy is rejected.
+	model.SimplexCrash = SimplexCrash(-1)
+	_, err := model.ToRawModel()
+	if err == nil {
+		t.Fatal("expected ToRawModel to reject a crash strategy of -1")
+	}
+}
+
+// TestIsNetworkFlow tests that IsNetworkFlow recognizes a transportation
+// problem, expressed as a network flow from two sources to two sinks, and
+// rejects a model that is not a network.
+func TestIsNetworkFlow(t *testing.T) {
+	// Arcs: x_0 = s_0→d_0, x_1 = s_0→d_1, x_2 = s_1→d_0, x_3 = s_1→d_1.
+	var model Model
+	model.ColCosts = []float64{4.0, 6.0, 5.0, 3.0}
+	model.ColLower = []float64{0.0, 0.0, 0.0, 0.0}
+	model.AddDenseRow(10.0, []float64{1.0, 1.0, 0.0, 0.0}, 10.0)   // s_0 supplies 10
+	model.AddDenseRow(8.0, []float64{0.0, 0.0, 1.0, 1.0}, 8.0)     // s_1 supplies 8
+	model.AddDenseRow(-9.0, []float64{-1.0, 0.0, -1.0, 0.0}, -9.0) // d_0 demands 9
+	model.AddDenseRow(-9.0, []float64{0.0, -1.0, 0.0, -1.0}, -9.0) // d_1 demands 9
+	if !model.IsNetworkFlow() {
+		t.Fatal("IsNetworkFlow failed to recognize a transportation problem")
+	}
+
+	// The minimal API model is not a network.
+	if minimalAPIModel(false).IsNetworkFlow() {
+		t.Fatal("IsNetworkFlow incorrectly recognized a non-network model")
+	}
+}
+
+// TestHash tests that equivalent models hash identically and that different
+// models hash differently.
+func TestHash(t *testing.T) {
+	// Construct the minimal API model in two different ways.
+	m1 := minimalAPIModel(false)
+	var m2 Model
+	m2.Offset = 3.0
+	m2.ColCosts = []float64{1.0, 1.0}
+	m2.ColLower = []float64{0.0, 1.0}
+	m2.ColUpper = []float64{4.0, math.Inf(1)}
+	m2.RowLower = []float64{math.Inf(-1), 5.0, 6.0}
+	m2.RowUpper = []float64{7.0, 15.0, math.Inf(1)}
+	m2.ConstMatrix = []Nonzero{
+		{2, 1, 2.0},
+		{1, 1, 2.0},
+		{0, 1, 1.0},
+		{2, 0, 3.0},
+		{1, 0, 1.0},
+	}
+	m2.VarTypes = []VariableType{ContinuousType, ContinuousType}
+	h1, h2 := m1.Hash(), m2.Hash()
+	if h1 != h2 {
+		t.Fatalf("equivalent models hashed differently (%s vs. %s)", h1, h2)
+	}
+
+	// Ensure that zero scale factors are equivalent to no scaling but that
+	// other scale factors change the hash.
+	m2.RowScale = []float64{0.0, 0.0, 0.0}
+	if h := m2.Hash(); h != h1 {
+		t.Fatalf("zero row scale factors changed the hash (%s vs. %s)", h, h1)
+	}
+	m2.ColScale = []float64{2.0, 0.0}
+	if h := m2.Hash(); h == h1 {
+		t.Fatal("scaling a column left the hash unchanged")
+	}
+	m2.ColScale = nil
+
+	// Ensure that lowering InfinityBound changes the hash, as a finite
+	// bound becomes infinite.
+	m2.InfinityBound = 10.0
+	if h := m2.Hash(); h == h1 {
+		t.Fatal("lowering InfinityBound left the hash unchanged")
+	}
+	m2.InfinityBound = 0.0
+
+	// Ensure that changing a coefficient changes the hash.
+	m2.ConstMatrix[0].Val = 2.5
+	if h3 := m2.Hash(); h3 == h1 {
+		t.Fatal("different models hashed identically")
+	}
+}
+
+// TestSolveWithModelDump tests that SolveWithModelDump returns a valid MPS
+// representation of the model it solved.
+func TestSolveWithModelDump(t *testing.T) {
+	// Solve the model and capture the MPS representation.
+	soln, dump, err := minimalAPIModel(false).SolveWithModelDump()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+	}
+	if !strings.Contains(dump, "ROWS") || !strings.Contains(dump, "COLUMNS") {
+		t.Fatalf("dump does not appear to be in MPS format:\n%s", dump)
+	}
+
+	// Re-solve the dumped model and compare objective values.
+	raw := NewRawModel()
+	checkErr(t, raw.ReadModel(strings.NewReader(dump)))
+	soln2, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln2.Objective != soln.Objective {
+		t.Fatalf("re-solving the dump produced an objective of %v instead of %v",
+			soln2.Objective, soln.Objective)
+	}
+
+	// A scaled model's solution is returned in unscaled units.
+	model := minimalAPIModel(false)
+	model.ColScale = []float64{2.0, 0.5}
+	model.RowScale = []float64{4.0, 0.0, 0.25}
+	soln, _, err = model.SolveWithModelDump()
+	if err != nil {
+		t.Fatal(err)
+	}
+	compSlices(t, "ColumnPrimal", roundFloats(1e-6, soln.ColumnPrimal), []float64{0.5, 2.25})
+	compSlices(t, "RowPrimal", roundFloats(1e-6, soln.RowPrimal), []float64{2.25, 5.0, 6.0})
+}
+
+// TestToRawModelContext tests that cancelling the context passed to
+// ToRawModelContext aborts the construction of a large model.
+func TestToRawModelContext(t *testing.T) {
+	// Construct a model with a large number of nonzeros.
+	const n = 1000
+	var model Model
+	model.ConstMatrix = make([]Nonzero, 0, n*n)
+	for r := n - 1; r >= 0; r-- {
+		for c := n - 1; c >= 0; c-- {
+			model.ConstMatrix = append(model.ConstMatrix, Nonzero{r, c, 1.0})
+		}
+	}
+
+	// Cancel the construction shortly after it begins.
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(time.Millisecond, cancel)
+	begin := time.Now()
+	_, err := model.ToRawModelContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled but received %v", err)
+	}
+	if elapsed := time.Since(begin); elapsed > time.Second {
+		t.Fatalf("ToRawModelContext took %v to notice the cancellation", elapsed)
+	}
+}
+
+// TestMatrixRank tests that MatrixRank detects a constraint that duplicates a
+// multiple of another.
+func TestMatrixRank(t *testing.T) {
+	model := minimalAPIModel(false)
+	rank, err := model.MatrixRank()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rank != 2 {
+		t.Fatalf("expected rank 2 but saw %d", rank)
+	}
+
+	// A 3×3 matrix whose last row is twice its first has rank 2.
+	var dup Model
+	dup.AddDenseRow(0.0, []float64{1.0, 2.0, 3.0}, 1.0)
+	dup.AddDenseRow(0.0, []float64{0.0, 1.0, 4.0}, 1.0)
+	dup.AddDenseRow(0.0, []float64{2.0, 4.0, 6.0}, 2.0)
+	rank, err = dup.MatrixRank()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rank != 2 {
+		t.Fatalf("expected rank 2 but saw %d", rank)
+	}
+}
+
+// TestDiffModels tests that DiffModels names the variable whose cost was
+// changed and reports nothing for equivalent models.
+func TestDiffModels(t *testing.T) {
+	a := minimalAPIModel(false)
+	a.ColNames = []string{"x", "y"}
+	b := minimalAPIModel(false)
+	b.ColNames = []string{"x", "y"}
+	b.RowLower[0] = math.Inf(-1) // Equivalent to a's -1e30
+	if diffs := DiffModels(a, b); len(diffs) != 0 {
+		t.Fatalf("expected no differences but saw %q", diffs)
+	}
+
+	// Change the cost of y.
+	b.ColCosts = []float64{1.0, 2.0}
+	diffs := DiffModels(a, b)
+	expected := []string{"column y: cost changed from 1 to 2"}
+	if len(diffs) != 1 || diffs[0] != expected[0] {
+		t.Fatalf("expected %q but saw %q", expected, diffs)
+	}
+}
+
+// TestEvaluate tests that Evaluate reproduces the objective value and row
+// activities of the TestMinimalAPIMin model's optimum and rejects an
+// infeasible point.
+func TestEvaluate(t *testing.T) {
+	model := minimalAPIModel(false)
+	obj, rows, feas, err := model.Evaluate([]float64{0.5, 2.25})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj != 5.75 {
+		t.Fatalf("objective value was %v but should have been 5.75", obj)
+	}
+	compSlices(t, "rowActivity", rows, []float64{2.25, 5.0, 6.0})
+	if !feas {
+		t.Fatal("Evaluate rejected a feasible point")
+	}
+
+	// The origin violates x_1's lower bound and two rows.
+	_, _, feas, err = model.Evaluate([]float64{0.0, 0.0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if feas {
+		t.Fatal("Evaluate accepted an infeasible point")
+	}
+
+	// Points of the wrong length are rejected.
+	_, _, _, err = model.Evaluate([]float64{0.0})
+	if err == nil {
+		t.Fatal("Evaluate accepted a point of the wrong length")
+	}
+}
+
+// TestHasSymmetry tests that HasSymmetry detects two identical columns in an
+// assignment-style model and finds no symmetry in the minimal API model.
+func TestHasSymmetry(t *testing.T) {
+	// Columns 0 and 1 are interchangeable; column 2 differs in cost.
+	var model Model
+	model.ColCosts = []float64{1.0, 1.0, 2.0}
+	model.ColLower = []float64{0.0, 0.0, 0.0}
+	model.ColUpper = []float64{1.0, 1.0, 1.0}
+	model.VarTypes = []VariableType{IntegerType, IntegerType, IntegerType}
+	model.AddDenseRow(1.0, []float64{1.0, 1.0, 1.0}, 1.0)
+	if !model.HasSymmetry() {
+		t.Fatal("HasSymmetry failed to detect identical columns")
+	}
+	if minimalAPIModel(false).HasSymmetry() {
+		t.Fatal("HasSymmetry incorrectly detected symmetry")
+	}
+}
+
+// TestToRawModelWithProgress tests that ToRawModelWithProgress reports
+// nondecreasing fractions ending in 1 while converting a large model.
+func TestToRawModelWithProgress(t *testing.T) {
+	// Construct a model with enough nonzeros to trigger intermediate
+	// progress reports.
+	const n = 1 << 9
+	var model Model
+	model.ConstMatrix = make([]Nonzero, 0, n*n)
+	for r := 0; r < n; r++ {
+		for c := 0; c < n; c++ {
+			model.ConstMatrix = append(model.ConstMatrix, Nonzero{r, c, 1.0})
+		}
+	}
+
+	// Convert the model, recording each progress report.
+	var fracs []float64
+	_, err := model.ToRawModelWithProgress(func(f float64) {
+		fracs = append(fracs, f)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fracs) < 4 {
+		t.Fatalf("expected at least 4 progress reports but saw %v", fracs)
+	}
+	for i := 1; i < len(fracs); i++ {
+		if fracs[i] < fracs[i-1] {
+			t.Fatalf("progress decreased from %v to %v", fracs[i-1], fracs[i])
+		}
+	}
+	if fracs[len(fracs)-1] != 1.0 {
+		t.Fatalf("final progress report was %v, not 1", fracs[len(fracs)-1])
+	}
+}
+
+// TestModelString tests that a model is rendered in algebraic form.
+func TestModelString(t *testing.T) {
+	model := minimalAPIModel(false)
+	str := model.String()
+	for _, want := range []string{
+		"min c0 + c1 + 3",
+		"c1 <= 7",
+		"5 <= c0 + 2*c1 <= 15",
+		"3*c0 + 2*c1 >= 6",
+		"0 <= c0 <= 4",
+	} {
+		if !strings.Contains(str, want) {
+			t.Fatalf("expected %q to contain %q", str, want)
+		}
+	}
+
+	// Confirm that column names and variable types are honored.
+	model.Maximize = true
+	model.ColNames = []string{"x", ""}
+	model.VarTypes = []VariableType{IntegerType, ContinuousType}
+	str = model.String()
+	for _, want := range []string{"max x + c1 + 3", "integer\n  x"} {
+		if !strings.Contains(str, want) {
+			t.Fatalf("expected %q to contain %q", str, want)
+		}
+	}
+
+	// Confirm that a duplicate coefficient replaces the original, as in
+	// ToRawModel, and that an invalid index is reported, not panicked on.
+	model = minimalAPIModel(false)
+	model.ConstMatrix = append(model.ConstMatrix, Nonzero{0, 1, 5.0})
+	if str = model.String(); !strings.Contains(str, "\n  5*c1 <= 7") {
+		t.Fatalf("expected %q to contain a single merged term for row 0", str)
+	}
+	model.ConstMatrix = append(model.ConstMatrix, Nonzero{-1, 0, 1.0})
+	if str = model.String(); !strings.HasPrefix(str, "invalid model: ") {
+		t.Fatalf("expected an invalid-model message but saw %q", str)
+	}
+}
+
+// TestValidate tests that Validate detects crossed bounds.
+func TestValidate(t *testing.T) {
+	model := minimalAPIModel(false)
+	checkErr(t, model.Validate())
+
+	// Cross a column's bounds.
+	model.ColNames = []string{"x", "y"}
+	model.ColLower[1] = 8.0
+	model.ColUpper[1] = 2.0
+	err := model.Validate()
+	if !errors.Is(err, ErrInfeasible) {
+		t.Fatalf("expected ErrInfeasible but saw %v", err)
+	}
+	if !strings.Contains(err.Error(), "column 1 (y)") {
+		t.Fatalf("expected %q to name column y", err)
+	}
+
+	// Cross a row's bounds as well.
+	model.RowLower[1] = 20.0
+	err = model.Validate()
+	if !errors.Is(err, ErrInfeasible) || !strings.Contains(err.Error(), "row 1") {
+		t.Fatalf("expected an error naming row 1 but saw %v", err)
+	}
+
+	// NaN bounds are construction errors rather than infeasibilities.
+	model = minimalAPIModel(false)
+	model.ColUpper[0] = math.NaN()
+	model.RowLower[2] = math.NaN()
+	err = model.Validate()
+	if err == nil || errors.Is(err, ErrInfeasible) {
+		t.Fatalf("expected a non-ErrInfeasible error but saw %v", err)
+	}
+	if !strings.Contains(err.Error(), "column 0") || !strings.Contains(err.Error(), "row 2") {
+		t.Fatalf("expected %q to name column 0 and row 2", err)
+	}
+}
+
+// TestApplySolution tests that ApplySolution stores a solution's primal
+// values in the model.
+func TestApplySolution(t *testing.T) {
+	model := minimalAPIModel(false)
+	soln := solveRaw(t, model)
+	model.ApplySolution(soln)
+	compSlices(t, "Solution", roundFloats(1e-6, model.Solution), []float64{0.5, 2.25})
+
+	// The stored values are a copy.
+	soln.ColumnPrimal[0] = 100.0
+	if model.Solution[0] == 100.0 {
+		t.Fatal("ApplySolution failed to copy the primal values")
+	}
+}
+
+// TestSanitize tests that Sanitize removes or reports NaN coefficients
+// according to its policy and reports values it cannot remove.
+func TestSanitize(t *testing.T) {
+	// Report a NaN coefficient without modifying the model.
+	model := minimalAPIModel(false)
+	model.ConstMatrix = append([]Nonzero{{0, 0, math.NaN()}}, model.ConstMatrix...)
+	removed, err := model.Sanitize(SanitizeError)
+	if err == nil || !strings.Contains(err.Error(), "matrix coefficient (0, 0) is NaN") {
+		t.Fatalf("expected an error naming coefficient (0, 0) but saw %v", err)
+	}
+	if removed != 0 || len(model.ConstMatrix) != 6 {
+		t.Fatalf("expected no coefficients to be removed but saw %d (%d remain)",
+			removed, len(model.ConstMatrix))
+	}
+
+	// Remove the NaN coefficient without modifying the caller's slice.
+	orig := model.ConstMatrix
+	removed, err = model.Sanitize(SanitizeRemove)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 1 || len(model.ConstMatrix) != 5 {
+		t.Fatalf("expected 1 coefficient to be removed but saw %d (%d remain)",
+			removed, len(model.ConstMatrix))
+	}
+	if !math.IsNaN(orig[0].Val) {
+		t.Fatal("Sanitize modified the original ConstMatrix")
+	}
+
+	// Report a NaN cost.
+	model.ColCosts[1] = math.NaN()
+	_, err = model.Sanitize(SanitizeRemove)
+	if err == nil || !strings.Contains(err.Error(), "column 1 (c1) has cost NaN") {
+		t.Fatalf("expected an error naming column 1's cost but saw %v", err)
+	}
+}
+
+// TestRHS tests that RHS converts row bounds to right-hand sides and senses.
+func TestRHS(t *testing.T) {
+	model := minimalAPIModel(false)
+	model.AddDenseRow(2.0, []float64{1.0, -1.0}, 2.0)
+	rhs, senses := model.RHS()
+	compSlices(t, "rhs", rhs, []float64{7.0, 15.0, 6.0, 2.0})
+	exp := []ConstraintSense{ConstraintLessEqual, ConstraintRanged, ConstraintGreaterEqual, ConstraintEqual}
+	if len(senses) != len(exp) {
+		t.Fatalf("expected senses %v but saw %v", exp, senses)
+	}
+	for r, s := range senses {
+		if s != exp[r] {
+			t.Fatalf("expected senses %v but saw %v", exp, senses)
+		}
+	}
+}
@@ -24,11 +24,14 @@ func _() {
 	_ = x[ObjectiveTarget-13]
 	_ = x[TimeLimit-14]
 	_ = x[IterationLimit-15]
+	_ = x[Unknown-16]
+	_ = x[SolutionLimit-17]
+	_ = x[Interrupt-18]
 }
 
-const _ModelStatus_name = "UnknownModelStatusNotSetLoadErrorModelErrorPresolveErrorSolveErrorPostsolveErrorModelEmptyOptimalInfeasibleUnboundedOrInfeasibleUnboundedObjectiveBoundObjectiveTargetTimeLimitIterationLimit"
+const _ModelStatus_name = "UnknownModelStatusNotSetLoadErrorModelErrorPresolveErrorSolveErrorPostsolveErrorModelEmptyOptimalInfeasibleUnboundedOrInfeasibleUnboundedObjectiveBoundObjectiveTargetTimeLimitIterationLimitUnknownSolutionLimitInterrupt"
 
-var _ModelStatus_index = [...]uint8{0, 18, 24, 33, 43, 56, 66, 80, 90, 97, 107, 128, 137, 151, 166, 175, 189}
+var _ModelStatus_index = [...]uint8{0, 18, 24, 33, 43, 56, 66, 80, 90, 97, 107, 128, 137, 151, 166, 175, 189, 196, 209, 218}
 
 func (i ModelStatus) String() string {
 	if i < 0 || i >= ModelStatus(len(_ModelStatus_index)-1) {
@@ -46,3 +46,87 @@ func TestMinimalAPIQPMin(t *testing.T) {
 		t.Fatalf("objective value was %.2f but should have been -5.25", soln.Objective)
 	}
 }
+
+// TestQPStationarity solves the TestMinimalAPIQPMin model and confirms that
+// the stationarity residual, Qx + c − Aᵀy − z, is small.
+func TestQPStationarity(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.ColCosts = []float64{0.0, -1.0, -3.0}
+	model.AddDenseRow(-1e30, []float64{1.0, 0.0, 1.0}, 2.0)
+	model.HessianMatrix = []Nonzero{
+		{0, 0, 2.0},
+		{0, 2, -1.0},
+		{1, 1, 0.2},
+		{2, 2, 2.0},
+	}
+
+	// Solve the model.
+	soln, err := model.Solve()
+	if err != nil {
+		t.Fatalf("Solve failed (%s)", err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+	}
+	if soln.ColumnDual == nil || soln.RowDual == nil {
+		t.Fatal("Solve did not return dual values")
+	}
+
+	// Compute the stationarity residual.  Q is symmetric, but only its
+	// upper triangle is stored.
+	resid := make([]float64, len(model.ColCosts))
+	copy(resid, model.ColCosts)
+	for _, nz := range model.HessianMatrix {
+		resid[nz.Row] += nz.Val * soln.ColumnPrimal[nz.Col]
+		if nz.Row != nz.Col {
+			resid[nz.Col] += nz.Val * soln.ColumnPrimal[nz.Row]
+		}
+	}
+	for _, nz := range model.ConstMatrix {
+		resid[nz.Col] -= nz.Val * soln.RowDual[nz.Row]
+	}
+	for c, z := range soln.ColumnDual {
+		if r := math.Abs(resid[c] - z); r > 1e-8 {
+			t.Fatalf("column %d has a stationarity residual of %v", c, r)
+		}
+	}
+}
+
+// TestSeparableQP minimizes (x−3)² + (y−2)² = x² − 6x + y² − 4y + 13 over a
+// box and confirms that the optimum lies at the unconstrained minimizer,
+// (3, 2).  Moving the box then confirms that the bounds are honored.
+func TestSeparableQP(t *testing.T) {
+	// Prepare the model.
+	var model Model
+	model.ColCosts = []float64{-6.0, -4.0}
+	model.Offset = 13.0
+	model.ColLower = []float64{0.0, 0.0}
+	model.ColUpper = []float64{10.0, 10.0}
+	model.AddDenseRow(math.Inf(-1), []float64{1.0, 1.0}, 20.0)
+	model.HessianMatrix = []Nonzero{
+		{0, 0, 2.0},
+		{1, 1, 2.0},
+	}
+
+	// Solve the model.
+	soln, err := model.Solve()
+	if err != nil {
+		t.Fatalf("Solve failed (%s)", err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+	}
+	compSlices(t, "ColumnPrimal", roundFloats(0.001, soln.ColumnPrimal), []float64{3.0, 2.0})
+	if math.Abs(soln.Objective) > 1e-6 {
+		t.Fatalf("objective value was %v but should have been 0", soln.Objective)
+	}
+
+	// Exclude (3, 2) by capping x at 1.
+	model.ColUpper[0] = 1.0
+	soln, err = model.Solve()
+	if err != nil {
+		t.Fatalf("Solve failed (%s)", err)
+	}
+	compSlices(t, "ColumnPrimal", roundFloats(0.001, soln.ColumnPrimal), []float64{1.0, 2.0})
+}
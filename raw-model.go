@@ -4,12 +4,17 @@
 package highs
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"math"
 	"os"
 	"runtime"
+	"strings"
+	"sync"
+	"time"
 	"unsafe"
 )
 
@@ -20,7 +25,34 @@ import "C"
 
 // A RawModel represents a HiGHS low-level model.
 type RawModel struct {
-	obj unsafe.Pointer
+	obj         unsafe.Pointer
+	colBasis    []C.HighsInt // Column basis from the most recent solve, if valid
+	rowBasis    []C.HighsInt // Row basis from the most recent solve, if valid
+	lastWarning *CallStatus  // Most recent warning returned by HiGHS
+}
+
+// DefaultSilent specifies whether NewRawModel and Model.ToRawModel disable
+// HiGHS's status output, which can be surprising when the highs package is
+// used as a library.  It defaults to true.  To view HiGHS's status output,
+// either set DefaultSilent to false before creating a model or set the model's
+// output_flag option to true.
+var DefaultSilent = true
+
+// infinity caches the value returned by Infinity.
+var infinity struct {
+	sync.Once
+	v float64
+}
+
+// Infinity returns the value HiGHS uses to represent an infinite bound.
+// Bounds at or beyond ±Infinity() are treated as absent.
+func Infinity() float64 {
+	infinity.Do(func() {
+		obj := C.Highs_create()
+		infinity.v = float64(C.Highs_getInfinity(obj))
+		C.Highs_destroy(obj)
+	})
+	return infinity.v
 }
 
 // NewRawModel allocates and returns an empty raw model.
@@ -28,13 +60,66 @@ func NewRawModel() *RawModel {
 	model := &RawModel{}
 	model.obj = C.Highs_create()
 	runtime.SetFinalizer(model, func(m *RawModel) {
+		forgetCallbacks(m.obj)
 		C.Highs_destroy(m.obj)
 	})
+	if DefaultSilent {
+		// Setting a valid option is not expected to fail.
+		model.SetBoolOption("output_flag", false)
+	}
 	return model
 }
 
-// ReadModelFromFile overwrites the model with a model read in MPS format from
-// a named file.
+// recordStatus is a variant of the newCallStatus function that additionally
+// records warnings for later retrieval by LastWarning.
+func (m *RawModel) recordStatus(st C.HighsInt, hName, gName string) error {
+	err := newCallStatus(st, hName, gName)
+	if cs, ok := err.(CallStatus); ok && cs.IsWarning() {
+		m.lastWarning = &cs
+	}
+	return err
+}
+
+// LastWarning returns the most recent warning HiGHS issued in response to an
+// operation on the model or nil if HiGHS has issued no warnings.  This lets
+// callers log warnings that the highs package ignores or that the caller
+// chose to tolerate.
+func (m *RawModel) LastWarning() *CallStatus {
+	return m.lastWarning
+}
+
+// free immediately releases the HiGHS object underlying a model rather than
+// waiting for the garbage collector to do so.  The model must not be used
+// thereafter.
+func (m *RawModel) free() {
+	runtime.SetFinalizer(m, nil)
+	forgetCallbacks(m.obj)
+	C.Highs_destroy(m.obj)
+	m.obj = nil
+}
+
+// NumCols returns the number of columns in the model.
+func (m *RawModel) NumCols() int {
+	return int(C.Highs_getNumCol(m.obj))
+}
+
+// NumRows returns the number of rows in the model.
+func (m *RawModel) NumRows() int {
+	return int(C.Highs_getNumRow(m.obj))
+}
+
+// NumNonzeros returns the number of nonzero coefficients in the model's
+// constraint matrix.
+func (m *RawModel) NumNonzeros() int {
+	return int(C.Highs_getNumNz(m.obj))
+}
+
+// ReadModelFromFile overwrites the model with a model read from a named file.
+// As in HiGHS, the file format is determined by the filename extension: ".lp"
+// for CPLEX LP format, ".mps" or ".mps.gz" for MPS format, and ".ems" for
+// HiGHS's own EMS format.  HiGHS rejects files with any other extension, in
+// which case ReadModelFromFile returns an error.  Use ReadModel to read an
+// MPS file with an arbitrary name.
 func (m *RawModel) ReadModelFromFile(fn string) error {
 	// Convert the filename argument from Go to C.
 	fName := C.CString(fn)
@@ -42,7 +127,7 @@ func (m *RawModel) ReadModelFromFile(fn string) error {
 
 	// Read into the model.
 	status := C.Highs_readModel(m.obj, fName)
-	return newCallStatus(status, "Highs_readModel", "ReadModelFromFile")
+	return m.recordStatus(status, "Highs_readModel", "ReadModelFromFile")
 }
 
 // ReadModel overwrites the model with a model read in MPS format from an
@@ -70,18 +155,53 @@ func (m *RawModel) ReadModel(r io.Reader) error {
 
 	// Read into the model.
 	status := C.Highs_readModel(m.obj, cFName)
-	return newCallStatus(status, "Highs_readModel", "ReadModel")
+	return m.recordStatus(status, "Highs_readModel", "ReadModel")
 }
 
-// WriteModelToFile writes a model in MPS format to a named file.
+// nativeModelSuffixes lists the filename suffixes from which HiGHS itself
+// determines a model file's format.
+var nativeModelSuffixes = []string{".mps", ".mps.gz", ".lp", ".ems"}
+
+// WriteModelToFile writes a model to a named file.  The file format is
+// determined by the filename extension: ".lp" for CPLEX LP format, ".mps" for
+// MPS format, and ".ems" for HiGHS's own EMS format.  Names ending in
+// ".mps.gz" are also passed to HiGHS unchanged.  Files with any other
+// extension are written in MPS format.
 func (m *RawModel) WriteModelToFile(fn string) error {
+	// Write files with unrecognized extensions via a throwaway MPS file.
+	native := false
+	for _, sfx := range nativeModelSuffixes {
+		if strings.HasSuffix(fn, sfx) {
+			native = true
+			break
+		}
+	}
+	if !native {
+		contents, err := withTempFile("highs-*.mps", nil, func(cFName *C.char) error {
+			status := C.Highs_writeModel(m.obj, cFName)
+			runtime.KeepAlive(m)
+			return m.recordStatus(status, "Highs_writeModel", "WriteModelToFile")
+		})
+		var cs CallStatus
+		if err != nil && !(errors.As(err, &cs) && cs.IsWarning()) {
+			return err
+		}
+		wErr := os.WriteFile(fn, contents, 0o666)
+		if wErr != nil {
+			return wErr
+		}
+		return err
+	}
+
 	// Convert the filename argument from Go to C.
 	cFName := C.CString(fn)
 	defer C.free(unsafe.Pointer(cFName))
 
-	// Write the model.
+	// Write the model.  Ensure that m is not finalized, freeing the
+	// underlying HiGHS object, while HiGHS is still writing it.
 	status := C.Highs_writeModel(m.obj, cFName)
-	return newCallStatus(status, "Highs_writeModel", "WriteModelToFile")
+	runtime.KeepAlive(m)
+	return m.recordStatus(status, "Highs_writeModel", "WriteModelToFile")
 }
 
 // WriteModel writes a model in MPS format to an io.Writer.
@@ -104,16 +224,13 @@ func (m *RawModel) WriteModel(w io.Writer) error {
 
 	// Write the model to the throwaway file.
 	status := C.Highs_writeModel(m.obj, cFName)
-	err = newCallStatus(status, "Highs_writeModel", "WriteModel")
+	runtime.KeepAlive(m)
+	wErr := m.recordStatus(status, "Highs_writeModel", "WriteModel")
 
 	// Ignore warnings (common for Highs_writeModel).
 	var cs CallStatus
-	if errors.As(err, &cs) {
-		if !cs.IsWarning() {
-			return err
-		}
-	} else if err != nil {
-		return err
+	if wErr != nil && !(errors.As(wErr, &cs) && cs.IsWarning()) {
+		return wErr
 	}
 
 	// Copy the contents of the throwaway file to the io.Writer.
@@ -129,7 +246,168 @@ func (m *RawModel) WriteModel(w io.Writer) error {
 	if err != nil {
 		return err
 	}
-	return cs // Propagate any warnings.
+	return wErr // Propagate any warnings.
+}
+
+// withTempFile creates an empty throwaway file with a given name pattern,
+// optionally fills it with data, invokes a function on its name, and returns
+// the file's final contents.  Failures other than warnings returned by the
+// function abort withTempFile.  Warnings are propagated.
+func withTempFile(pattern string, data []byte, fn func(cFName *C.char) error) ([]byte, error) {
+	// Create and populate the throwaway file.
+	tFile, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return nil, err
+	}
+	fName := tFile.Name()
+	defer os.Remove(fName)
+	_, err = tFile.Write(data)
+	if err != nil {
+		tFile.Close()
+		return nil, err
+	}
+	err = tFile.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// Invoke the function on the file.
+	cFName := C.CString(fName)
+	defer C.free(unsafe.Pointer(cFName))
+	fErr := fn(cFName)
+	var cs CallStatus
+	if fErr != nil && !(errors.As(fErr, &cs) && cs.IsWarning()) {
+		return nil, fErr
+	}
+
+	// Return the file's contents.
+	contents, err := os.ReadFile(fName)
+	if err != nil {
+		return nil, err
+	}
+	return contents, fErr
+}
+
+// A savedState is the serialized form of a model's state, as written by
+// SaveState and read by LoadState.
+type savedState struct {
+	Model    string    `json:"model"`               // Model in MPS format
+	Options  string    `json:"options"`             // Non-default options in HiGHS's options-file format
+	ColValue []float64 `json:"col_value,omitempty"` // Best known primal column values
+	ColBasis []int     `json:"col_basis,omitempty"` // Column basis from the most recent solve
+	RowBasis []int     `json:"row_basis,omitempty"` // Row basis from the most recent solve
+}
+
+// SaveState writes to an io.Writer, in JSON format, everything needed to
+// resume work on a model in another process: the model itself, all options
+// with non-default values, the best-known primal solution (e.g., a MIP
+// incumbent), and the basis from the most recent solve, if valid.  HiGHS
+// cannot resume a branch-and-bound search midway, but a model restored by
+// LoadState begins its next solve from the saved solution and basis.
+func (m *RawModel) SaveState(w io.Writer) error {
+	// Capture the model and the non-default options.
+	var state savedState
+	var buf bytes.Buffer
+	err := m.WriteModel(&buf)
+	var cs CallStatus
+	if err != nil && !(errors.As(err, &cs) && cs.IsWarning()) {
+		return err
+	}
+	state.Model = buf.String()
+	opts, err := withTempFile("highs-*.txt", nil, func(cFName *C.char) error {
+		status := C.Highs_writeOptionsDeviations(m.obj, cFName)
+		return m.recordStatus(status, "Highs_writeOptionsDeviations", "SaveState")
+	})
+	if err != nil && !(errors.As(err, &cs) && cs.IsWarning()) {
+		return err
+	}
+	state.Options = string(opts)
+
+	// Capture the primal solution, if feasible.
+	var pss C.HighsInt
+	str := C.CString("primal_solution_status")
+	defer C.free(unsafe.Pointer(str))
+	status := C.Highs_getIntInfoValue(m.obj, str, &pss)
+	nc := int(C.Highs_getNumCol(m.obj))
+	nr := int(C.Highs_getNumRow(m.obj))
+	if status == C.kHighsStatusOk && pss == C.kHighsSolutionStatusFeasible && nc > 0 {
+		colValue := make([]C.double, nc)
+		colDual := make([]C.double, nc)
+		rowValue := make([]C.double, nr)
+		rowDual := make([]C.double, nr)
+		status = C.Highs_getSolution(m.obj, sliceToPointer(colValue), sliceToPointer(colDual),
+			sliceToPointer(rowValue), sliceToPointer(rowDual))
+		err = m.recordStatus(status, "Highs_getSolution", "SaveState")
+		if err != nil {
+			return err
+		}
+		state.ColValue = convertSlice[float64, C.double](colValue)
+	}
+
+	// Capture the basis, if valid.
+	if m.colBasis != nil && m.rowBasis != nil {
+		state.ColBasis = convertSlice[int, C.HighsInt](m.colBasis)
+		state.RowBasis = convertSlice[int, C.HighsInt](m.rowBasis)
+	}
+	return json.NewEncoder(w).Encode(state)
+}
+
+// LoadState overwrites the model, its options, its starting solution, and its
+// starting basis with those written by SaveState to an io.Reader.
+func (m *RawModel) LoadState(r io.Reader) error {
+	// Read the state and restore the model.
+	var state savedState
+	err := json.NewDecoder(r).Decode(&state)
+	if err != nil {
+		return err
+	}
+	err = m.ReadModel(strings.NewReader(state.Model))
+	var cs CallStatus
+	if err != nil && !(errors.As(err, &cs) && cs.IsWarning()) {
+		return err
+	}
+
+	// Restore the options.
+	status := C.Highs_resetOptions(m.obj)
+	err = m.recordStatus(status, "Highs_resetOptions", "LoadState")
+	if err != nil {
+		return err
+	}
+	_, err = withTempFile("highs-*.txt", []byte(state.Options), func(cFName *C.char) error {
+		status := C.Highs_readOptions(m.obj, cFName)
+		return m.recordStatus(status, "Highs_readOptions", "LoadState")
+	})
+	if err != nil && !(errors.As(err, &cs) && cs.IsWarning()) {
+		return err
+	}
+
+	// Restore the starting solution and basis.
+	nc := int(C.Highs_getNumCol(m.obj))
+	nr := int(C.Highs_getNumRow(m.obj))
+	m.colBasis, m.rowBasis = nil, nil
+	if len(state.ColValue) > 0 {
+		if len(state.ColValue) != nc {
+			return fmt.Errorf("saved solution has %d column(s) but the model has %d",
+				len(state.ColValue), nc)
+		}
+		colValue := convertSlice[C.double, float64](state.ColValue)
+		status = C.Highs_setSolution(m.obj, &colValue[0], nil, nil, nil)
+		err = m.recordStatus(status, "Highs_setSolution", "LoadState")
+		if err != nil && !(errors.As(err, &cs) && cs.IsWarning()) {
+			return err
+		}
+	}
+	if len(state.ColBasis) == nc && len(state.RowBasis) == nr && nc > 0 {
+		colBasis := convertSlice[C.HighsInt, int](state.ColBasis)
+		rowBasis := convertSlice[C.HighsInt, int](state.RowBasis)
+		status = C.Highs_setBasis(m.obj, sliceToPointer(colBasis), sliceToPointer(rowBasis))
+		err = m.recordStatus(status, "Highs_setBasis", "LoadState")
+		if err != nil {
+			return err
+		}
+		m.colBasis, m.rowBasis = colBasis, rowBasis
+	}
+	return nil
 }
 
 // SetBoolOption assigns a Boolean value to a named option.
@@ -144,7 +422,7 @@ func (m *RawModel) SetBoolOption(opt string, v bool) error {
 
 	// Set the option.
 	status := C.Highs_setBoolOptionValue(m.obj, str, val)
-	return newCallStatus(status, "Highs_setBoolOptionValue", "SetBoolOption")
+	return m.recordStatus(status, "Highs_setBoolOptionValue", "SetBoolOption")
 }
 
 // SetIntOption assigns an integer value to a named option.
@@ -156,7 +434,7 @@ func (m *RawModel) SetIntOption(opt string, v int) error {
 
 	// Set the option.
 	status := C.Highs_setIntOptionValue(m.obj, str, val)
-	return newCallStatus(status, "Highs_setIntOptionValue", "SetIntOption")
+	return m.recordStatus(status, "Highs_setIntOptionValue", "SetIntOption")
 }
 
 // SetFloat64Option assigns a floating-point value to a named option.
@@ -168,7 +446,7 @@ func (m *RawModel) SetFloat64Option(opt string, v float64) error {
 
 	// Set the option.
 	status := C.Highs_setDoubleOptionValue(m.obj, str, val)
-	return newCallStatus(status, "Highs_setDoubleOptionValue", "SetFloat64Option")
+	return m.recordStatus(status, "Highs_setDoubleOptionValue", "SetFloat64Option")
 }
 
 // SetStringOption assigns a string value to a named option.
@@ -181,7 +459,7 @@ func (m *RawModel) SetStringOption(opt string, v string) error {
 
 	// Set the option.
 	status := C.Highs_setStringOptionValue(m.obj, str, val)
-	return newCallStatus(status, "Highs_setStringOptionValue", "SetStringOption")
+	return m.recordStatus(status, "Highs_setStringOptionValue", "SetStringOption")
 }
 
 // GetBoolOption returns the Boolean value of a named option.
@@ -193,7 +471,7 @@ func (m *RawModel) GetBoolOption(opt string) (bool, error) {
 	// Get the value.
 	var val C.HighsInt
 	status := C.Highs_getBoolOptionValue(m.obj, str, &val)
-	err := newCallStatus(status, "Highs_getBoolOptionValue", "GetBoolOption")
+	err := m.recordStatus(status, "Highs_getBoolOptionValue", "GetBoolOption")
 	if err != nil {
 		return false, err
 	}
@@ -213,7 +491,7 @@ func (m *RawModel) GetIntOption(opt string) (int, error) {
 	// Get the value.
 	var val C.HighsInt
 	status := C.Highs_getIntOptionValue(m.obj, str, &val)
-	err := newCallStatus(status, "Highs_getIntOptionValue", "GetIntOption")
+	err := m.recordStatus(status, "Highs_getIntOptionValue", "GetIntOption")
 	if err != nil {
 		return 0, err
 	}
@@ -229,7 +507,7 @@ func (m *RawModel) GetFloat64Option(opt string) (float64, error) {
 	// Get the value.
 	var val C.double
 	status := C.Highs_getDoubleOptionValue(m.obj, str, &val)
-	err := newCallStatus(status, "Highs_getDoubleOptionValue", "GetFloat64Option")
+	err := m.recordStatus(status, "Highs_getDoubleOptionValue", "GetFloat64Option")
 	if err != nil {
 		return 0.0, err
 	}
@@ -251,13 +529,254 @@ func (m *RawModel) GetStringOption(opt string) (string, error) {
 
 	// Get the value.
 	status := C.Highs_getStringOptionValue(m.obj, str, val)
-	err := newCallStatus(status, "Highs_getStringOptionValue", "GetStringOption")
+	err := m.recordStatus(status, "Highs_getStringOptionValue", "GetStringOption")
 	if err != nil {
 		return "", err
 	}
 	return C.GoString(val), nil
 }
 
+// An Options value records the values of a selection of commonly used HiGHS
+// options.
+type Options struct {
+	Solver                     string  // Solver to use ("simplex", "ipm", "choose", etc.)
+	Presolve                   string  // Whether to presolve ("off", "choose", or "on")
+	Parallel                   string  // Whether to solve in parallel ("off", "choose", or "on")
+	Threads                    int     // Number of threads to use (0=automatic)
+	RandomSeed                 int     // Random-number seed
+	SimplexStrategy            int     // Simplex strategy (kSimplexStrategy* value)
+	TimeLimit                  float64 // Time limit in seconds
+	MIPRelGap                  float64 // Relative MIP gap at which to terminate
+	PrimalFeasibilityTolerance float64 // Primal feasibility tolerance
+	DualFeasibilityTolerance   float64 // Dual feasibility tolerance
+}
+
+// effectiveOptions reads back the current values of the options recorded in
+// an Options value.
+func (m *RawModel) effectiveOptions() (Options, error) {
+	var opts Options
+	var err error
+	for name, p := range map[string]*string{
+		"solver":   &opts.Solver,
+		"presolve": &opts.Presolve,
+		"parallel": &opts.Parallel,
+	} {
+		if *p, err = m.GetStringOption(name); err != nil {
+			return Options{}, err
+		}
+	}
+	for name, p := range map[string]*int{
+		"threads":          &opts.Threads,
+		"random_seed":      &opts.RandomSeed,
+		"simplex_strategy": &opts.SimplexStrategy,
+	} {
+		if *p, err = m.GetIntOption(name); err != nil {
+			return Options{}, err
+		}
+	}
+	for name, p := range map[string]*float64{
+		"time_limit":                   &opts.TimeLimit,
+		"mip_rel_gap":                  &opts.MIPRelGap,
+		"primal_feasibility_tolerance": &opts.PrimalFeasibilityTolerance,
+		"dual_feasibility_tolerance":   &opts.DualFeasibilityTolerance,
+	} {
+		if *p, err = m.GetFloat64Option(name); err != nil {
+			return Options{}, err
+		}
+	}
+	return opts, nil
+}
+
+// optionNames returns the names of all HiGHS options.
+func (m *RawModel) optionNames() ([]string, error) {
+	n := int(C.Highs_getNumOptions(m.obj))
+	names := make([]string, n)
+	for i := range names {
+		var name *C.char
+		status := C.Highs_getOptionName(m.obj, C.HighsInt(i), &name)
+		err := m.recordStatus(status, "Highs_getOptionName", "optionNames")
+		if err != nil {
+			return nil, err
+		}
+		names[i] = C.GoString(name)
+		C.free(unsafe.Pointer(name))
+	}
+	return names, nil
+}
+
+// NonDefaultOptions returns the name and current value of each option whose
+// value differs from HiGHS's default.  Values are of type bool, int, float64,
+// or string, according to the option's type.  Note that output_flag is
+// reported as a non-default option when DefaultSilent is true.
+func (m *RawModel) NonDefaultOptions() (map[string]any, error) {
+	names, err := m.optionNames()
+	if err != nil {
+		return nil, err
+	}
+	opts := make(map[string]any)
+	for _, name := range names {
+		v, changed, err := m.nonDefaultValue(name)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			opts[name] = v
+		}
+	}
+	return opts, nil
+}
+
+// nonDefaultValue returns the current value of a named option and an
+// indication of whether that value differs from HiGHS's default.
+func (m *RawModel) nonDefaultValue(opt string) (any, bool, error) {
+	// Determine the option's type.
+	const gName = "NonDefaultOptions"
+	str := C.CString(opt)
+	defer C.free(unsafe.Pointer(str))
+	oType, err := m.optionType(str, gName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Compare the option's current value to its default value.
+	var status C.HighsInt
+	switch oType {
+	case C.kHighsOptionTypeBool:
+		var cur, def C.HighsInt
+		status = C.Highs_getBoolOptionValues(m.obj, str, &cur, &def)
+		err = m.recordStatus(status, "Highs_getBoolOptionValues", gName)
+		return cur != 0, cur != def, err
+	case C.kHighsOptionTypeInt:
+		var cur, min, max, def C.HighsInt
+		status = C.Highs_getIntOptionValues(m.obj, str, &cur, &min, &max, &def)
+		err = m.recordStatus(status, "Highs_getIntOptionValues", gName)
+		return int(cur), cur != def, err
+	case C.kHighsOptionTypeDouble:
+		var cur, min, max, def C.double
+		status = C.Highs_getDoubleOptionValues(m.obj, str, &cur, &min, &max, &def)
+		err = m.recordStatus(status, "Highs_getDoubleOptionValues", gName)
+		return float64(cur), cur != def, err
+	case C.kHighsOptionTypeString:
+		// As in GetStringOption, allocate "enough" memory.
+		cur := (*C.char)(C.calloc(65536, 1))
+		defer C.free(unsafe.Pointer(cur))
+		def := (*C.char)(C.calloc(65536, 1))
+		defer C.free(unsafe.Pointer(def))
+		status = C.Highs_getStringOptionValues(m.obj, str, cur, def)
+		err = m.recordStatus(status, "Highs_getStringOptionValues", gName)
+		return C.GoString(cur), C.GoString(cur) != C.GoString(def), err
+	default:
+		return nil, false, fmt.Errorf("option %q has unrecognized type %d", opt, oType)
+	}
+}
+
+// optionType returns the kHighsOptionType of a named option.
+func (m *RawModel) optionType(str *C.char, gName string) (C.HighsInt, error) {
+	var oType C.HighsInt
+	status := C.Highs_getOptionType(m.obj, str, &oType)
+	err := m.recordStatus(status, "Highs_getOptionType", gName)
+	return oType, err
+}
+
+// OptionType returns the type of value a named option accepts.
+func (m *RawModel) OptionType(opt string) (OptionType, error) {
+	str := C.CString(opt)
+	defer C.free(unsafe.Pointer(str))
+	oType, err := m.optionType(str, "OptionType")
+	if err != nil {
+		return 0, err
+	}
+	switch oType {
+	case C.kHighsOptionTypeBool:
+		return BoolOption, nil
+	case C.kHighsOptionTypeInt:
+		return IntOption, nil
+	case C.kHighsOptionTypeDouble:
+		return Float64Option, nil
+	case C.kHighsOptionTypeString:
+		return StringOption, nil
+	default:
+		return 0, fmt.Errorf("option %q has unrecognized type %d", opt, oType)
+	}
+}
+
+// OptionBounds returns the minimum, maximum, and default values of a named
+// numeric option.  The values of an IntOption are converted to float64.
+// OptionBounds returns an error for options that are not numeric.
+func (m *RawModel) OptionBounds(opt string) (min, max, def float64, err error) {
+	const gName = "OptionBounds"
+	str := C.CString(opt)
+	defer C.free(unsafe.Pointer(str))
+	oType, err := m.optionType(str, gName)
+	if err != nil {
+		return 0.0, 0.0, 0.0, err
+	}
+	var status C.HighsInt
+	switch oType {
+	case C.kHighsOptionTypeInt:
+		var cCur, cMin, cMax, cDef C.HighsInt
+		status = C.Highs_getIntOptionValues(m.obj, str, &cCur, &cMin, &cMax, &cDef)
+		err = m.recordStatus(status, "Highs_getIntOptionValues", gName)
+		return float64(cMin), float64(cMax), float64(cDef), err
+	case C.kHighsOptionTypeDouble:
+		var cCur, cMin, cMax, cDef C.double
+		status = C.Highs_getDoubleOptionValues(m.obj, str, &cCur, &cMin, &cMax, &cDef)
+		err = m.recordStatus(status, "Highs_getDoubleOptionValues", gName)
+		return float64(cMin), float64(cMax), float64(cDef), err
+	default:
+		return 0.0, 0.0, 0.0, fmt.Errorf("option %q is not numeric", opt)
+	}
+}
+
+// OptionDescription returns the human-readable description HiGHS provides
+// for a named option.  HiGHS's C API does not expose option descriptions
+// directly, so OptionDescription extracts them from the commented options file
+// HiGHS writes.
+func (m *RawModel) OptionDescription(opt string) (string, error) {
+	// Have HiGHS write all options to a throwaway file.
+	text, err := withTempFile("highs-*.txt", nil, func(cFName *C.char) error {
+		status := C.Highs_writeOptions(m.obj, cFName)
+		return m.recordStatus(status, "Highs_writeOptions", "OptionDescription")
+	})
+	var cs CallStatus
+	if err != nil && !(errors.As(err, &cs) && cs.IsWarning()) {
+		return "", err
+	}
+
+	// Each option is written as one or more lines of description
+	// comments, a comment describing the option's type, and a
+	// "name = value" line.
+	var desc []string
+	for _, line := range strings.Split(string(text), "\n") {
+		switch {
+		case strings.HasPrefix(line, "# ["):
+			// Type information
+		case strings.HasPrefix(line, "#"):
+			desc = append(desc, strings.TrimSpace(strings.TrimPrefix(line, "#")))
+		case strings.TrimSpace(line) == "":
+			desc = nil
+		default:
+			name, _, _ := strings.Cut(line, "=")
+			if strings.TrimSpace(name) == opt {
+				return strings.Join(desc, " "), nil
+			}
+			desc = nil
+		}
+	}
+	return "", fmt.Errorf("unknown option %q", opt)
+}
+
+// SetTimeLimit limits the wall-clock time, in seconds, that a subsequent
+// Solve may spend.  A limit of math.Inf(1) removes the limit.  If Solve is cut
+// short by the time limit, it returns the best solution found so far, with a
+// Status of TimeLimit, along with a warning.
+func (m *RawModel) SetTimeLimit(seconds float64) error {
+	if seconds <= 0.0 || math.IsNaN(seconds) {
+		return fmt.Errorf("the time limit must be positive but is %v", seconds)
+	}
+	return m.SetFloat64Option("time_limit", seconds)
+}
+
 // SetMaximization tells a model to maximize (true) or minimize (false) its
 // objective function.
 func (m *RawModel) SetMaximization(max bool) error {
@@ -266,7 +785,7 @@ func (m *RawModel) SetMaximization(max bool) error {
 		sense = C.kHighsObjSenseMaximize
 	}
 	status := C.Highs_changeObjectiveSense(m.obj, sense)
-	return newCallStatus(status, "Highs_changeObjectiveSense", "SetMaximization")
+	return m.recordStatus(status, "Highs_changeObjectiveSense", "SetMaximization")
 }
 
 // SetColumnCosts specifies a model's column costs (i.e., its objective
@@ -276,13 +795,108 @@ func (m *RawModel) SetColumnCosts(cs []float64) error {
 	status := C.Highs_changeColsCostByRange(m.obj,
 		0, C.HighsInt(len(cs)-1),
 		&cost[0])
-	return newCallStatus(status, "Highs_changeColsCostByRange", "SetColumnCosts")
+	return m.recordStatus(status, "Highs_changeColsCostByRange", "SetColumnCosts")
+}
+
+// ChangeColumnCost replaces the cost of a single column.  A subsequent Solve
+// is warm-started from the current basis.
+func (m *RawModel) ChangeColumnCost(col int, cost float64) error {
+	nc := int(C.Highs_getNumCol(m.obj))
+	if col < 0 || col >= nc {
+		return fmt.Errorf("column %d is out of range [0, %d)", col, nc)
+	}
+	status := C.Highs_changeColCost(m.obj, C.HighsInt(col), C.double(cost))
+	return m.recordStatus(status, "Highs_changeColCost", "ChangeColumnCost")
+}
+
+// ChangeColumnCosts replaces the costs of a set of columns.  A subsequent
+// Solve is warm-started from the current basis.
+func (m *RawModel) ChangeColumnCosts(cols []int, costs []float64) error {
+	// Check for simple errors.
+	if len(cols) != len(costs) {
+		return fmt.Errorf("cols and costs must be the same length (%d vs. %d)",
+			len(cols), len(costs))
+	}
+	nc := int(C.Highs_getNumCol(m.obj))
+	for _, c := range cols {
+		if c < 0 || c >= nc {
+			return fmt.Errorf("column %d is out of range [0, %d)", c, nc)
+		}
+	}
+	if len(cols) == 0 {
+		return nil
+	}
+
+	// Change the costs.
+	hSet := convertSlice[C.HighsInt, int](cols)
+	hCost := convertSlice[C.double, float64](costs)
+	status := C.Highs_changeColsCostBySet(m.obj, C.HighsInt(len(cols)), &hSet[0], &hCost[0])
+	return m.recordStatus(status, "Highs_changeColsCostBySet", "ChangeColumnCosts")
+}
+
+// checkColumnBounds returns an error if a column is out of range, if its
+// lower bound is +Inf or its upper bound is -Inf, or if its finite lower bound
+// exceeds its finite upper bound.
+func checkColumnBounds(nc, col int, lower, upper float64) error {
+	switch {
+	case col < 0 || col >= nc:
+		return fmt.Errorf("column %d is out of range [0, %d)", col, nc)
+	case math.IsInf(lower, 1):
+		return fmt.Errorf("column %d's lower bound is +Inf", col)
+	case math.IsInf(upper, -1):
+		return fmt.Errorf("column %d's upper bound is -Inf", col)
+	case math.IsInf(lower, 0) || math.IsInf(upper, 0):
+		return nil
+	case lower > upper:
+		return fmt.Errorf("column %d's lower bound (%v) exceeds its upper bound (%v)", col, lower, upper)
+	default:
+		return nil
+	}
+}
+
+// ChangeColumnBounds replaces the lower and upper bounds of a single column.
+// Setting both bounds to the same value fixes the column at that value.
+func (m *RawModel) ChangeColumnBounds(col int, lower, upper float64) error {
+	err := checkColumnBounds(int(C.Highs_getNumCol(m.obj)), col, lower, upper)
+	if err != nil {
+		return err
+	}
+	status := C.Highs_changeColBounds(m.obj, C.HighsInt(col), C.double(lower), C.double(upper))
+	return m.recordStatus(status, "Highs_changeColBounds", "ChangeColumnBounds")
+}
+
+// ChangeColumnsBounds replaces the lower and upper bounds of a set of
+// columns.
+func (m *RawModel) ChangeColumnsBounds(cols []int, lower, upper []float64) error {
+	// Check for simple errors.
+	if len(cols) != len(lower) || len(cols) != len(upper) {
+		return fmt.Errorf("cols, lower, and upper must be the same length (%d vs. %d vs. %d)",
+			len(cols), len(lower), len(upper))
+	}
+	nc := int(C.Highs_getNumCol(m.obj))
+	for i, c := range cols {
+		err := checkColumnBounds(nc, c, lower[i], upper[i])
+		if err != nil {
+			return err
+		}
+	}
+	if len(cols) == 0 {
+		return nil
+	}
+
+	// Change the bounds.
+	hSet := convertSlice[C.HighsInt, int](cols)
+	hLower := convertSlice[C.double, float64](lower)
+	hUpper := convertSlice[C.double, float64](upper)
+	status := C.Highs_changeColsBoundsBySet(m.obj, C.HighsInt(len(cols)),
+		&hSet[0], &hLower[0], &hUpper[0])
+	return m.recordStatus(status, "Highs_changeColsBoundsBySet", "ChangeColumnsBounds")
 }
 
 // SetOffset specifies a constant offset for the objective function.
 func (m *RawModel) SetOffset(o float64) error {
 	status := C.Highs_changeObjectiveOffset(m.obj, C.double(o))
-	return newCallStatus(status, "Highs_changeObjectiveOffset", "SetOffset")
+	return m.recordStatus(status, "Highs_changeObjectiveOffset", "SetOffset")
 }
 
 // prepareBounds replaces nil column or row bounds with infinities.
@@ -292,14 +906,14 @@ func prepareBounds(lb, ub []float64) ([]float64, []float64, error) {
 		// No bounds were provided.
 	case lb == nil:
 		// Replace nil lower bounds with minus infinity.
-		mInf := math.Inf(-1)
+		mInf := -Infinity()
 		lb = make([]float64, len(ub))
 		for i := range lb {
 			lb[i] = mInf
 		}
 	case ub == nil:
 		// Replace nil upper bounds with plus infinity.
-		pInf := math.Inf(1)
+		pInf := Infinity()
 		ub = make([]float64, len(lb))
 		for i := range ub {
 			ub[i] = pInf
@@ -323,7 +937,7 @@ func (m *RawModel) AddColumnBounds(lb, ub []float64) error {
 	upper := convertSlice[C.double, float64](colUpper)
 	status := C.Highs_addVars(m.obj, C.HighsInt(len(lower)),
 		&lower[0], &upper[0])
-	return newCallStatus(status, "Highs_addVars", "SetColumnBounds")
+	return m.recordStatus(status, "Highs_addVars", "SetColumnBounds")
 }
 
 // AddCompSparseRows appends compressed sparse rows to the model.
@@ -347,7 +961,7 @@ func (m *RawModel) AddCompSparseRows(lb []float64, start []int, index []int, val
 	status := C.Highs_addRows(m.obj, C.HighsInt(len(lb)),
 		&hLower[0], &hUpper[0],
 		C.HighsInt(len(value)), &hStart[0], &hIndex[0], &hValue[0])
-	return newCallStatus(status, "Highs_addRows", "AddCompSparseRows")
+	return m.recordStatus(status, "Highs_addRows", "AddCompSparseRows")
 }
 
 // AddDenseRow is a convenience function that lets the caller add to the model
@@ -370,7 +984,102 @@ func (m *RawModel) AddDenseRow(lb float64, coeffs []float64, ub float64) error {
 	// Add the row.
 	status := C.Highs_addRow(m.obj, C.double(lb), C.double(ub),
 		numNewNz, &index[0], &value[0])
-	return newCallStatus(status, "Highs_addRow", "AddDenseRow")
+	return m.recordStatus(status, "Highs_addRow", "AddDenseRow")
+}
+
+// AddColumn appends a single column to the model, specifying its cost, its
+// lower and upper bounds, and its nonzero coefficients in existing rows.
+// AddColumn is intended for column-generation algorithms, in which columns are
+// discovered between solves.
+func (m *RawModel) AddColumn(cost, lower, upper float64, rowIndices []int, values []float64) error {
+	// Check for simple errors.
+	if len(rowIndices) != len(values) {
+		return fmt.Errorf("rowIndices and values must be the same length (%d vs. %d)",
+			len(rowIndices), len(values))
+	}
+	nr := int(C.Highs_getNumRow(m.obj))
+	for _, r := range rowIndices {
+		if r < 0 || r >= nr {
+			return fmt.Errorf("row %d is out of range [0, %d)", r, nr)
+		}
+	}
+
+	// Add the column.
+	hIndex := convertSlice[C.HighsInt, int](rowIndices)
+	hValue := convertSlice[C.double, float64](values)
+	status := C.Highs_addCol(m.obj, C.double(cost), C.double(lower), C.double(upper),
+		C.HighsInt(len(values)), sliceToPointer(hIndex), sliceToPointer(hValue))
+	return m.recordStatus(status, "Highs_addCol", "AddColumn")
+}
+
+// DeleteColumns deletes columns from through to, inclusive, from the model.
+// The indices of all subsequent columns shift down accordingly.  Because the
+// basis found by the most recent solve no longer matches the model, it is
+// discarded for the purpose of RepairBasis.
+func (m *RawModel) DeleteColumns(from, to int) error {
+	nc := int(C.Highs_getNumCol(m.obj))
+	if from < 0 || to >= nc || from > to {
+		return fmt.Errorf("column range [%d, %d] is invalid for a model with %d column(s)",
+			from, to, nc)
+	}
+	status := C.Highs_deleteColsByRange(m.obj, C.HighsInt(from), C.HighsInt(to))
+	m.colBasis, m.rowBasis = nil, nil
+	return m.recordStatus(status, "Highs_deleteColsByRange", "DeleteColumns")
+}
+
+// AddRow appends a single row to the model, specifying its lower and upper
+// bounds and its nonzero coefficients in existing columns.  AddRow is
+// intended for cutting-plane and lazy-constraint algorithms, in which rows
+// are added between solves.
+func (m *RawModel) AddRow(lower, upper float64, colIndices []int, values []float64) error {
+	// Check for simple errors.
+	if len(colIndices) != len(values) {
+		return fmt.Errorf("colIndices and values must be the same length (%d vs. %d)",
+			len(colIndices), len(values))
+	}
+	nc := int(C.Highs_getNumCol(m.obj))
+	for _, c := range colIndices {
+		if c < 0 || c >= nc {
+			return fmt.Errorf("column %d is out of range [0, %d)", c, nc)
+		}
+	}
+
+	// Add the row.
+	hIndex := convertSlice[C.HighsInt, int](colIndices)
+	hValue := convertSlice[C.double, float64](values)
+	status := C.Highs_addRow(m.obj, C.double(lower), C.double(upper),
+		C.HighsInt(len(values)), sliceToPointer(hIndex), sliceToPointer(hValue))
+	return m.recordStatus(status, "Highs_addRow", "AddRow")
+}
+
+// DeleteRows deletes rows from through to, inclusive, from the model.  The
+// indices of all subsequent rows shift down accordingly.  Because the basis
+// found by the most recent solve no longer matches the model, it is discarded
+// for the purpose of RepairBasis.
+func (m *RawModel) DeleteRows(from, to int) error {
+	nr := int(C.Highs_getNumRow(m.obj))
+	if from < 0 || to >= nr || from > to {
+		return fmt.Errorf("row range [%d, %d] is invalid for a model with %d row(s)",
+			from, to, nr)
+	}
+	status := C.Highs_deleteRowsByRange(m.obj, C.HighsInt(from), C.HighsInt(to))
+	m.colBasis, m.rowBasis = nil, nil
+	return m.recordStatus(status, "Highs_deleteRowsByRange", "DeleteRows")
+}
+
+// ChangeCoefficient replaces a single coefficient in the constraint matrix.
+// Setting a coefficient to zero removes it from the matrix.
+func (m *RawModel) ChangeCoefficient(row, col int, value float64) error {
+	nr := int(C.Highs_getNumRow(m.obj))
+	if row < 0 || row >= nr {
+		return fmt.Errorf("row %d is out of range [0, %d)", row, nr)
+	}
+	nc := int(C.Highs_getNumCol(m.obj))
+	if col < 0 || col >= nc {
+		return fmt.Errorf("column %d is out of range [0, %d)", col, nc)
+	}
+	status := C.Highs_changeCoeff(m.obj, C.HighsInt(row), C.HighsInt(col), C.double(value))
+	return m.recordStatus(status, "Highs_changeCoeff", "ChangeCoefficient")
 }
 
 // SetIntegrality specifies the type of each column (variable) in the model.
@@ -382,7 +1091,30 @@ func (m *RawModel) SetIntegrality(ts []VariableType) error {
 	status := C.Highs_changeColsIntegralityByRange(m.obj,
 		0, C.HighsInt(len(integrality)-1),
 		&integrality[0])
-	return newCallStatus(status, "Highs_changeColsIntegralityByRange", "SetIntegrality")
+	return m.recordStatus(status, "Highs_changeColsIntegralityByRange", "SetIntegrality")
+}
+
+// ChangeColsIntegralityByRange specifies the type of each column (variable)
+// in the inclusive range [from, to].  The length of types must equal the
+// number of columns in the range.
+func (m *RawModel) ChangeColsIntegralityByRange(from, to int, types []VariableType) error {
+	nc := int(C.Highs_getNumCol(m.obj))
+	if from < 0 || to >= nc || from > to {
+		return fmt.Errorf("column range [%d, %d] is invalid for a model with %d column(s)", from, to, nc)
+	}
+	if len(types) != to-from+1 {
+		return fmt.Errorf("expected %d variable types but received %d", to-from+1, len(types))
+	}
+	integrality := make([]C.HighsInt, len(types))
+	for i, t := range types {
+		if t < ContinuousType || int(t) >= len(variableTypeToHighs) {
+			return fmt.Errorf("invalid variable type %d", t)
+		}
+		integrality[i] = variableTypeToHighs[t]
+	}
+	status := C.Highs_changeColsIntegralityByRange(m.obj,
+		C.HighsInt(from), C.HighsInt(to), &integrality[0])
+	return m.recordStatus(status, "Highs_changeColsIntegralityByRange", "ChangeColsIntegralityByRange")
 }
 
 // AddCompSparseHessian assigns a Hessian in compressed sparse row form to the
@@ -402,19 +1134,518 @@ func (m *RawModel) AddCompSparseHessian(start []int, index []int, value []float6
 	status := C.Highs_passHessian(m.obj, C.HighsInt(len(start)),
 		C.HighsInt(len(value)), C.kHighsHessianFormatTriangular,
 		&hStart[0], &hIndex[0], &hValue[0])
-	return newCallStatus(status, "Highs_passHessian", "AddCompSparseHessian")
+	return m.recordStatus(status, "Highs_passHessian", "AddCompSparseHessian")
+}
+
+// passColName assigns a name to a single column.
+func (m *RawModel) passColName(col int, name string, gName string) error {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	status := C.Highs_passColName(m.obj, C.HighsInt(col), cName)
+	return m.recordStatus(status, "Highs_passColName", gName)
+}
+
+// changeRowBounds replaces the lower and upper bounds of a single row.
+func (m *RawModel) changeRowBounds(row int, lower, upper float64, gName string) error {
+	nr := int(C.Highs_getNumRow(m.obj))
+	if row < 0 || row >= nr {
+		return fmt.Errorf("row %d is out of range [0, %d)", row, nr)
+	}
+	status := C.Highs_changeRowBounds(m.obj, C.HighsInt(row), C.double(lower), C.double(upper))
+	return m.recordStatus(status, "Highs_changeRowBounds", gName)
+}
+
+// ChangeRowBounds replaces the lower and upper bounds of a single row.  A
+// subsequent Solve is warm-started from the current basis.
+func (m *RawModel) ChangeRowBounds(row int, lower, upper float64) error {
+	return m.changeRowBounds(row, lower, upper, "ChangeRowBounds")
+}
+
+// TightenRowBound replaces the lower and upper bounds of a single row then
+// re-solves the model, warm-starting from the current basis, and reports
+// whether the model remains feasible.  An error is returned if the solve
+// terminates in a way that leaves feasibility undetermined.
+func (m *RawModel) TightenRowBound(row int, newLower, newUpper float64) (stillFeasible bool, err error) {
+	err = m.changeRowBounds(row, newLower, newUpper, "TightenRowBound")
+	if err != nil {
+		return false, err
+	}
+	soln, err := m.Solve()
+	if err != nil {
+		return false, err
+	}
+	switch soln.Status {
+	case Optimal, Unbounded:
+		return true, nil
+	case Infeasible:
+		return false, nil
+	default:
+		return false, fmt.Errorf("the solve terminated with status %s, which does not determine feasibility", soln.Status)
+	}
+}
+
+// changeAllRowBounds replaces the lower and upper bounds of every row.
+func (m *RawModel) changeAllRowBounds(lower, upper []float64, gName string) error {
+	nr := int(C.Highs_getNumRow(m.obj))
+	if len(lower) != nr || len(upper) != nr {
+		return fmt.Errorf("expected %d lower and upper row bounds but received %d and %d",
+			nr, len(lower), len(upper))
+	}
+	if nr == 0 {
+		return nil
+	}
+	hLower := convertSlice[C.double, float64](lower)
+	hUpper := convertSlice[C.double, float64](upper)
+	status := C.Highs_changeRowsBoundsByRange(m.obj, 0, C.HighsInt(nr-1), &hLower[0], &hUpper[0])
+	return m.recordStatus(status, "Highs_changeRowsBoundsByRange", gName)
+}
+
+// An InfeasibilityReport explains why a model is infeasible.
+type InfeasibilityReport struct {
+	DualRay      []float64 // Dual ray (Farkas certificate) with one value per row (nil if unavailable)
+	RayRows      []int     // Rows with a nonzero value in DualRay
+	IIS          []int     // Rows forming an irreducible infeasible subsystem
+	Explanations []string  // Human-readable description of each row in IIS
+}
+
+// describeRow returns a human-readable description of a row, such as
+// "row 2: c0 + 2*c1 >= 5".
+func describeRow(r int, lower, upper float64, terms []Nonzero, names func(int) string) string {
+	vals := make([]float64, len(terms))
+	vars := make([]string, len(terms))
+	for i, nz := range terms {
+		vals[i] = nz.Val
+		vars[i] = names(nz.Col)
+	}
+	return fmt.Sprintf("row %d: %s", r, formatConstraint(formatExpr(vals, vars), lower, upper))
+}
+
+// DiagnoseInfeasibility explains why a model is infeasible.  It solves the
+// model, retrieves the dual ray if HiGHS provides one, and computes an
+// irreducible infeasible subsystem (IIS): a set of rows that, together with
+// the column bounds, is infeasible but becomes feasible if any one of them is
+// removed.  HiGHS's C API does not expose an IIS, so DiagnoseInfeasibility
+// computes one with a deletion filter, which re-solves the model once per
+// row.  The model's row bounds are restored on return.  An error is returned
+// if the model is not infeasible.  Quadratic models are not supported.
+func (m *RawModel) DiagnoseInfeasibility() (*InfeasibilityReport, error) {
+	// Acquire the model's rows so we can describe them later.
+	model, err := m.toModel()
+	if err != nil {
+		return nil, err
+	}
+	rowTerms := make([][]Nonzero, len(model.RowLower))
+	for _, nz := range model.ConstMatrix {
+		rowTerms[nz.Row] = append(rowTerms[nz.Row], nz)
+	}
+
+	// Confirm that the model is infeasible.
+	soln, err := m.Solve()
+	if err != nil {
+		return nil, err
+	}
+	if soln.Status != Infeasible {
+		return nil, fmt.Errorf("the model is not infeasible (status %s)", soln.Status)
+	}
+
+	// Acquire the dual ray, if any.
+	var rpt InfeasibilityReport
+	nr := len(model.RowLower)
+	if nr > 0 {
+		var hasRay C.HighsInt
+		ray := make([]C.double, nr)
+		status := C.Highs_getDualRay(m.obj, &hasRay, &ray[0])
+		err = m.recordStatus(status, "Highs_getDualRay", "DiagnoseInfeasibility")
+		if err == nil && hasRay != 0 {
+			rpt.DualRay = convertSlice[float64, C.double](ray)
+			for r, y := range rpt.DualRay {
+				if y != 0.0 {
+					rpt.RayRows = append(rpt.RayRows, r)
+				}
+			}
+		}
+	}
+
+	// Apply a deletion filter to find an IIS: Free each row in turn, and
+	// keep it free if the model remains infeasible without it.
+	defer m.changeAllRowBounds(model.RowLower, model.RowUpper, "DiagnoseInfeasibility")
+	mInf, pInf := math.Inf(-1), math.Inf(1)
+	for r := 0; r < nr; r++ {
+		err = m.changeRowBounds(r, mInf, pInf, "DiagnoseInfeasibility")
+		if err != nil {
+			return nil, err
+		}
+		soln, err = m.Solve()
+		var cs CallStatus
+		if err != nil && !(errors.As(err, &cs) && cs.IsWarning()) {
+			return nil, err
+		}
+		if soln.Status == Infeasible {
+			continue // Row r is not needed to explain the infeasibility.
+		}
+		err = m.changeRowBounds(r, model.RowLower[r], model.RowUpper[r], "DiagnoseInfeasibility")
+		if err != nil {
+			return nil, err
+		}
+		rpt.IIS = append(rpt.IIS, r)
+		rpt.Explanations = append(rpt.Explanations,
+			describeRow(r, model.RowLower[r], model.RowUpper[r], rowTerms[r], model.colName))
+	}
+	return &rpt, nil
 }
 
-// Solve solves a model.
+// SolveScenarios solves a model once per scenario, where each scenario
+// specifies a complete set of lower and upper row bounds (right-hand sides).
+// Each solve is warm-started from the basis left by the previous one, which is
+// typically much faster than solving each scenario from scratch.  The model's
+// original row bounds are restored on return.  Note that the info methods of
+// each returned RawSolution report values from the most recent solve.
+func (m *RawModel) SolveScenarios(rhsLower, rhsUpper [][]float64) ([]*RawSolution, error) {
+	if len(rhsLower) != len(rhsUpper) {
+		return nil, fmt.Errorf("rhsLower and rhsUpper must be the same length (%d vs. %d)",
+			len(rhsLower), len(rhsUpper))
+	}
+	origLower, origUpper, err := m.rowBounds()
+	if err != nil {
+		return nil, err
+	}
+	solns := make([]*RawSolution, len(rhsLower))
+	for i := range rhsLower {
+		err = m.changeAllRowBounds(rhsLower[i], rhsUpper[i], "SolveScenarios")
+		if err == nil {
+			solns[i], err = m.Solve()
+		}
+		var cs CallStatus
+		if err != nil && !(errors.As(err, &cs) && cs.IsWarning()) {
+			m.changeAllRowBounds(origLower, origUpper, "SolveScenarios")
+			return nil, fmt.Errorf("scenario %d: %w", i, err)
+		}
+	}
+	err = m.changeAllRowBounds(origLower, origUpper, "SolveScenarios")
+	if err != nil {
+		return nil, err
+	}
+	return solns, nil
+}
+
+// columnData returns a model's column costs and lower and upper column
+// bounds.
+func (m *RawModel) columnData() (cost, lower, upper []float64, err error) {
+	nc := C.Highs_getNumCol(m.obj)
+	if nc == 0 {
+		return []float64{}, []float64{}, []float64{}, nil
+	}
+	var numCol, numNz C.HighsInt
+	hCost := make([]C.double, nc)
+	hLower := make([]C.double, nc)
+	hUpper := make([]C.double, nc)
+	status := C.Highs_getColsByRange(m.obj, 0, nc-1, &numCol,
+		&hCost[0], &hLower[0], &hUpper[0], &numNz, nil, nil, nil)
+	err = m.recordStatus(status, "Highs_getColsByRange", "columnData")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	cost = convertSlice[float64, C.double](hCost)
+	lower = convertSlice[float64, C.double](hLower)
+	upper = convertSlice[float64, C.double](hUpper)
+	return cost, lower, upper, nil
+}
+
+// GetObjectiveCoefficients returns the cost of each column in the model, for
+// example to confirm that ChangeColumnCosts took effect.
+func (m *RawModel) GetObjectiveCoefficients() ([]float64, error) {
+	cost, _, _, err := m.columnData()
+	return cost, err
+}
+
+// RepairBasis extends the basis found by the most recent solve to cover any
+// rows and columns appended to the model since then, so that a subsequent
+// solve can still be warm-started.  Each new column is made nonbasic at one of
+// its bounds (or at zero if it has no finite bounds), and each new row is made
+// basic.  RepairBasis assumes that rows and columns have been appended but not
+// deleted since the most recent solve.  It returns an error if that solve did
+// not produce a valid basis.
+func (m *RawModel) RepairBasis() error {
+	// Ensure we have a basis to repair.
+	if m.colBasis == nil || m.rowBasis == nil {
+		return fmt.Errorf("no valid basis is available to repair")
+	}
+	nc := int(C.Highs_getNumCol(m.obj))
+	nr := int(C.Highs_getNumRow(m.obj))
+	if nc < len(m.colBasis) || nr < len(m.rowBasis) {
+		return fmt.Errorf("rows or columns were deleted since the basis was computed")
+	}
+
+	// Make new columns nonbasic.
+	_, lower, upper, err := m.columnData()
+	if err != nil {
+		return err
+	}
+	colBasis := make([]C.HighsInt, nc)
+	copy(colBasis, m.colBasis)
+	for c := len(m.colBasis); c < nc; c++ {
+		switch {
+		case math.Abs(lower[c]) < infiniteBound:
+			colBasis[c] = C.kHighsBasisStatusLower
+		case math.Abs(upper[c]) < infiniteBound:
+			colBasis[c] = C.kHighsBasisStatusUpper
+		default:
+			colBasis[c] = C.kHighsBasisStatusZero
+		}
+	}
+
+	// Make new rows basic.
+	rowBasis := make([]C.HighsInt, nr)
+	copy(rowBasis, m.rowBasis)
+	for r := len(m.rowBasis); r < nr; r++ {
+		rowBasis[r] = C.kHighsBasisStatusBasic
+	}
+
+	// Pass the repaired basis to HiGHS.
+	status := C.Highs_setBasis(m.obj, sliceToPointer(colBasis), sliceToPointer(rowBasis))
+	return m.recordStatus(status, "Highs_setBasis", "RepairBasis")
+}
+
+// SetBasis provides HiGHS with a basis from which to warm-start the next
+// solve, typically one returned by GetBasis before the model was modified
+// slightly.  colBasis and rowBasis must contain one status per column and
+// row, respectively.
+func (m *RawModel) SetBasis(colBasis, rowBasis []BasisStatus) error {
+	// Check for simple errors.
+	nc := int(C.Highs_getNumCol(m.obj))
+	nr := int(C.Highs_getNumRow(m.obj))
+	if len(colBasis) != nc {
+		return fmt.Errorf("expected %d column basis statuses but received %d", nc, len(colBasis))
+	}
+	if len(rowBasis) != nr {
+		return fmt.Errorf("expected %d row basis statuses but received %d", nr, len(rowBasis))
+	}
+
+	// Convert the basis from Go to C.
+	var err error
+	hColBasis := make([]C.HighsInt, nc)
+	for c, bs := range colBasis {
+		hColBasis[c], err = basisStatusToHighs(bs)
+		if err != nil {
+			return fmt.Errorf("column %d: %w", c, err)
+		}
+	}
+	hRowBasis := make([]C.HighsInt, nr)
+	for r, bs := range rowBasis {
+		hRowBasis[r], err = basisStatusToHighs(bs)
+		if err != nil {
+			return fmt.Errorf("row %d: %w", r, err)
+		}
+	}
+
+	// Pass the basis to HiGHS.
+	status := C.Highs_setBasis(m.obj, sliceToPointer(hColBasis), sliceToPointer(hRowBasis))
+	err = m.recordStatus(status, "Highs_setBasis", "SetBasis")
+	if err != nil {
+		return err
+	}
+	m.colBasis, m.rowBasis = hColBasis, hRowBasis
+	return nil
+}
+
+// GetBasis returns HiGHS's current basis: one status per column and one
+// status per row.
+func (m *RawModel) GetBasis() (colBasis, rowBasis []BasisStatus, err error) {
+	nc := int(C.Highs_getNumCol(m.obj))
+	nr := int(C.Highs_getNumRow(m.obj))
+	hColBasis := make([]C.HighsInt, nc+1) // +1 to avoid taking the address of an empty slice
+	hRowBasis := make([]C.HighsInt, nr+1)
+	status := C.Highs_getBasis(m.obj, &hColBasis[0], &hRowBasis[0])
+	err = m.recordStatus(status, "Highs_getBasis", "GetBasis")
+	if err != nil {
+		return nil, nil, err
+	}
+	colBasis = make([]BasisStatus, nc)
+	for c, hbs := range hColBasis[:nc] {
+		colBasis[c] = convertHighsBasisStatus(hbs)
+	}
+	rowBasis = make([]BasisStatus, nr)
+	for r, hbs := range hRowBasis[:nr] {
+		rowBasis[r] = convertHighsBasisStatus(hbs)
+	}
+	return colBasis, rowBasis, nil
+}
+
+// SetSolution provides HiGHS with a starting solution, which, for a
+// mixed-integer model, HiGHS uses as its initial incumbent if it is feasible.
+// colValues must contain a value for every column; HiGHS's C API does not
+// accept partial solutions, so NaN values are rejected.
+func (m *RawModel) SetSolution(colValues []float64) error {
+	// Check for simple errors.
+	nc := int(C.Highs_getNumCol(m.obj))
+	if len(colValues) != nc {
+		return fmt.Errorf("expected %d column values but received %d", nc, len(colValues))
+	}
+	for c, v := range colValues {
+		if math.IsNaN(v) {
+			return fmt.Errorf("column %d has no value", c)
+		}
+	}
+	if nc == 0 {
+		return nil
+	}
+
+	// Pass the solution to HiGHS.
+	hColValues := convertSlice[C.double, float64](colValues)
+	status := C.Highs_setSolution(m.obj, &hColValues[0], nil, nil, nil)
+	return m.recordStatus(status, "Highs_setSolution", "SetSolution")
+}
+
+// isMaximization reports whether a model is set to maximize (true) or
+// minimize (false) its objective function.
+func (m *RawModel) isMaximization() (bool, error) {
+	var sense C.HighsInt
+	status := C.Highs_getObjectiveSense(m.obj, &sense)
+	err := m.recordStatus(status, "Highs_getObjectiveSense", "isMaximization")
+	if err != nil {
+		return false, err
+	}
+	return sense == C.kHighsObjSenseMaximize, nil
+}
+
+// isMIP reports whether any of a model's columns is of a type other than
+// continuous.  HiGHS reports an error when asked for the integrality of a
+// model that has no integrality information.
+func (m *RawModel) isMIP() bool {
+	nc := int(C.Highs_getNumCol(m.obj))
+	var hvt C.HighsInt
+	for c := 0; c < nc; c++ {
+		if C.Highs_getColIntegrality(m.obj, C.HighsInt(c), &hvt) != C.kHighsStatusOk {
+			return false
+		}
+		if hvt != C.kHighsVarTypeContinuous {
+			return true
+		}
+	}
+	return false
+}
+
+// rowBounds returns a model's lower and upper row bounds.
+func (m *RawModel) rowBounds() ([]float64, []float64, error) {
+	nr := C.Highs_getNumRow(m.obj)
+	if nr == 0 {
+		return []float64{}, []float64{}, nil
+	}
+	var numRow, numNz C.HighsInt
+	lower := make([]C.double, nr)
+	upper := make([]C.double, nr)
+	status := C.Highs_getRowsByRange(m.obj, 0, nr-1, &numRow,
+		&lower[0], &upper[0], &numNz, nil, nil, nil)
+	err := m.recordStatus(status, "Highs_getRowsByRange", "rowBounds")
+	if err != nil {
+		return nil, nil, err
+	}
+	return convertSlice[float64, C.double](lower), convertSlice[float64, C.double](upper), nil
+}
+
+// toModel converts a low-level model to a high-level model.  Quadratic models
+// are not supported.
+func (m *RawModel) toModel() (*Model, error) {
+	if C.Highs_getHessianNumNz(m.obj) > 0 {
+		return nil, errors.New("quadratic models cannot be converted to high-level models")
+	}
+
+	// Acquire the objective function and the column and row bounds.
+	var model Model
+	var err error
+	model.Maximize, err = m.isMaximization()
+	if err != nil {
+		return nil, err
+	}
+	var offset C.double
+	status := C.Highs_getObjectiveOffset(m.obj, &offset)
+	err = m.recordStatus(status, "Highs_getObjectiveOffset", "toModel")
+	if err != nil {
+		return nil, err
+	}
+	model.Offset = float64(offset)
+	model.ColCosts, model.ColLower, model.ColUpper, err = m.columnData()
+	if err != nil {
+		return nil, err
+	}
+	model.RowLower, model.RowUpper, err = m.rowBounds()
+	if err != nil {
+		return nil, err
+	}
+
+	// Acquire the constraint matrix in CSR format and convert it to a
+	// list of nonzeros.
+	nr := int(C.Highs_getNumRow(m.obj))
+	nnz := int(C.Highs_getNumNz(m.obj))
+	if nr > 0 && nnz > 0 {
+		var numRow, numNz C.HighsInt
+		lower := make([]C.double, nr)
+		upper := make([]C.double, nr)
+		start := make([]C.HighsInt, nr)
+		index := make([]C.HighsInt, nnz)
+		value := make([]C.double, nnz)
+		status = C.Highs_getRowsByRange(m.obj, 0, C.HighsInt(nr-1), &numRow,
+			&lower[0], &upper[0], &numNz, &start[0], &index[0], &value[0])
+		err = m.recordStatus(status, "Highs_getRowsByRange", "toModel")
+		if err != nil {
+			return nil, err
+		}
+		model.ConstMatrix = make([]Nonzero, 0, int(numNz))
+		for r := 0; r < nr; r++ {
+			end := int(numNz)
+			if r < nr-1 {
+				end = int(start[r+1])
+			}
+			for k := int(start[r]); k < end; k++ {
+				model.ConstMatrix = append(model.ConstMatrix,
+					Nonzero{r, int(index[k]), float64(value[k])})
+			}
+		}
+	}
+
+	// Acquire the type of each column.  HiGHS reports an error if the
+	// model has no integrality information, in which case all columns
+	// are continuous.
+	nc := int(C.Highs_getNumCol(m.obj))
+	var hvt C.HighsInt
+	if nc > 0 && C.Highs_getColIntegrality(m.obj, 0, &hvt) == C.kHighsStatusOk {
+		model.VarTypes = make([]VariableType, nc)
+		for c := range model.VarTypes {
+			status = C.Highs_getColIntegrality(m.obj, C.HighsInt(c), &hvt)
+			err = m.recordStatus(status, "Highs_getColIntegrality", "toModel")
+			if err != nil {
+				return nil, err
+			}
+			model.VarTypes[c], err = convertHighsVarType(hvt)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &model, nil
+}
+
+// Solve solves a model.  If HiGHS stops early with a warning, for example
+// upon reaching an iteration or time limit, Solve returns the partial
+// solution along with the warning.
 func (m *RawModel) Solve() (*RawSolution, error) {
 	// Solve the model.  We assume the user has already set up all the
 	// required parameters.
+	resetCallbacks(m.obj)
 	status := C.Highs_run(m.obj)
-	err := newCallStatus(status, "Highs_run", "Solve")
-	if err != nil {
-		return &RawSolution{}, err
+	finishCallbacks(m.obj)
+	runErr := m.recordStatus(status, "Highs_run", "Solve")
+	var cs CallStatus
+	if runErr != nil && !(errors.As(runErr, &cs) && cs.IsWarning()) {
+		return &RawSolution{}, runErr
 	}
+	return m.extractSolution(runErr)
+}
 
+// extractSolution is a helper function for Solve and SolveTimed that
+// converts the solution HiGHS found from C to Go.  runErr, which is either nil
+// or a warning from Highs_run, is returned if no other error occurs.
+func (m *RawModel) extractSolution(runErr error) (*RawSolution, error) {
 	// Extract the solution as Go data.
 	var soln RawSolution
 	soln.rm = m
@@ -426,9 +1657,9 @@ func (m *RawModel) Solve() (*RawSolution, error) {
 	colDual := make([]C.double, nc)
 	rowValue := make([]C.double, nr)
 	rowDual := make([]C.double, nr)
-	status = C.Highs_getSolution(hObj, &colValue[0], &colDual[0],
+	status := C.Highs_getSolution(hObj, &colValue[0], &colDual[0],
 		&rowValue[0], &rowDual[0])
-	err = newCallStatus(status, "Highs_getSolution", "Solve")
+	err := m.recordStatus(status, "Highs_getSolution", "Solve")
 	if err != nil {
 		return &RawSolution{}, err
 	}
@@ -449,16 +1680,19 @@ func (m *RawModel) Solve() (*RawSolution, error) {
 		soln.RowDual = convertSlice[float64, C.double](rowDual)
 	}
 
-	// If basis data are available, convert them from C to Go.
+	// If basis data are available, convert them from C to Go.  Retain a
+	// copy for use by RepairBasis.
+	m.colBasis, m.rowBasis = nil, nil
 	bValid, err := soln.GetIntInfo("basis_validity")
 	if err == nil && bValid == int(C.kHighsBasisValidityValid) {
 		colBasisStatus := make([]C.HighsInt, nc)
 		rowBasisStatus := make([]C.HighsInt, nr)
 		status = C.Highs_getBasis(hObj, &colBasisStatus[0], &rowBasisStatus[0])
-		err = newCallStatus(status, "Highs_getBasis", "Solve")
+		err = m.recordStatus(status, "Highs_getBasis", "Solve")
 		if err != nil {
 			return &RawSolution{}, err
 		}
+		m.colBasis, m.rowBasis = colBasisStatus, rowBasisStatus
 		soln.ColumnBasis = make([]BasisStatus, nc)
 		for i, cbs := range colBasisStatus {
 			soln.ColumnBasis[i] = convertHighsBasisStatus(cbs)
@@ -468,5 +1702,93 @@ func (m *RawModel) Solve() (*RawSolution, error) {
 			soln.RowBasis[i] = convertHighsBasisStatus(rbs)
 		}
 	}
-	return &soln, nil
+
+	// For MIPs, record the gap and bound, which are meaningful even when
+	// the solve was interrupted.
+	soln.MipGap, soln.BestBound = math.NaN(), math.NaN()
+	if m.isMIP() {
+		soln.MipGap, err = soln.GetFloat64Info("mip_gap")
+		if err != nil {
+			return &RawSolution{}, err
+		}
+		soln.BestBound, err = soln.GetFloat64Info("mip_dual_bound")
+		if err != nil {
+			return &RawSolution{}, err
+		}
+	}
+
+	// Record the amount of work performed.  HiGHS reports counts that
+	// are inapplicable to the solver it used as unavailable or negative,
+	// in which case the count remains zero.
+	if n, err := soln.GetIntInfo("simplex_iteration_count"); err == nil && n > 0 {
+		soln.SimplexIterations = n
+	}
+	if n, err := soln.GetIntInfo("ipm_iteration_count"); err == nil && n > 0 {
+		soln.IpmIterations = n
+	}
+	if n, err := soln.GetInt64Info("mip_node_count"); err == nil && n > 0 {
+		soln.MipNodes = n
+	}
+	return &soln, runErr // Propagate any warnings.
+}
+
+// SolveSense solves a model with a given objective sense, temporarily
+// overriding the model's own sense.  The original sense is restored on return.
+// Solving a model once with each sense bounds the range of values the
+// objective function can take over the feasible region.
+func (m *RawModel) SolveSense(sense ObjSense) (*RawSolution, error) {
+	// Override the objective sense.
+	if sense != Minimize && sense != Maximize {
+		return &RawSolution{}, fmt.Errorf("invalid objective sense (%d)", sense)
+	}
+	origMax, err := m.isMaximization()
+	if err != nil {
+		return &RawSolution{}, err
+	}
+	err = m.SetMaximization(sense == Maximize)
+	if err != nil {
+		return &RawSolution{}, err
+	}
+
+	// Solve the model then restore the original sense.
+	soln, err := m.Solve()
+	rErr := m.SetMaximization(origMax)
+	if err == nil {
+		err = rErr
+	}
+	return soln, err
+}
+
+// SolveTiming reports where the time went during a solve.  HiGHS's C API
+// does not report presolve time separately, so it is included in Run and
+// HiGHS.
+type SolveTiming struct {
+	Build   time.Duration // Time to construct the low-level model (zero for RawModel.SolveTimed)
+	Run     time.Duration // Time spent in Highs_run, as measured by Go
+	HiGHS   time.Duration // Run time as measured by HiGHS itself
+	Extract time.Duration // Time to convert the solution from C to Go
+	Total   time.Duration // Total time spent in SolveTimed
+}
+
+// SolveTimed is a variant of Solve that additionally reports how long each
+// phase of the solve took.
+func (m *RawModel) SolveTimed() (*RawSolution, SolveTiming, error) {
+	var timing SolveTiming
+	begin := time.Now()
+	resetCallbacks(m.obj)
+	status := C.Highs_run(m.obj)
+	finishCallbacks(m.obj)
+	timing.Run = time.Since(begin)
+	runErr := m.recordStatus(status, "Highs_run", "SolveTimed")
+	var cs CallStatus
+	if runErr != nil && !(errors.As(runErr, &cs) && cs.IsWarning()) {
+		timing.Total = time.Since(begin)
+		return &RawSolution{}, timing, runErr
+	}
+	timing.HiGHS = time.Duration(float64(C.Highs_getRunTime(m.obj)) * float64(time.Second))
+	extBegin := time.Now()
+	soln, err := m.extractSolution(runErr)
+	timing.Extract = time.Since(extBegin)
+	timing.Total = time.Since(begin)
+	return soln, timing, err
 }
@@ -4,6 +4,8 @@
 package highs
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"unsafe"
@@ -17,12 +19,103 @@ import "C"
 // A RawSolution encapsulates all the values returned by various HiGHS solvers
 // and provides methods to retrieve additional information.
 type RawSolution struct {
-	rm       *RawModel // Model that produced the solution
-	Solution           // Values returned by the solver
+	rm                *RawModel // Model that produced the solution
+	Solution                    // Values returned by the solver
+	opts              *Options  // Options recorded by Clone
+	MipGap            float64   // Relative gap between the objective and BestBound (NaN for non-MIPs)
+	BestBound         float64   // Best proven bound on the objective value (NaN for non-MIPs)
+	SimplexIterations int       // Number of simplex iterations performed
+	IpmIterations     int       // Number of interior-point iterations performed
+	MipNodes          int64     // Number of branch-and-bound nodes explored
+}
+
+// A StatusError reports that a solve ended with a status other than Optimal.
+// Use errors.Is to compare it to ErrNotOptimal, which matches every
+// StatusError, or to one of the more specific errors ErrInfeasible,
+// ErrUnbounded, ErrTimeLimit, and ErrIterationLimit.  A StatusError for an
+// UnboundedOrInfeasible status matches both ErrInfeasible and ErrUnbounded.
+type StatusError struct {
+	Status ModelStatus // Status with which the solve ended
+}
+
+// Error returns a StatusError as a string.
+func (e StatusError) Error() string {
+	return fmt.Sprintf("solve ended with status %v", e.Status)
+}
+
+// Is reports whether a StatusError matches a target error.
+func (e StatusError) Is(target error) bool {
+	switch target {
+	case ErrNotOptimal:
+		return true
+	case ErrInfeasible:
+		return e.Status == Infeasible || e.Status == UnboundedOrInfeasible
+	case ErrUnbounded:
+		return e.Status == Unbounded || e.Status == UnboundedOrInfeasible
+	case ErrTimeLimit:
+		return e.Status == TimeLimit
+	case ErrIterationLimit:
+		return e.Status == IterationLimit
+	default:
+		return false
+	}
+}
+
+// Err returns nil if a solution is optimal and otherwise a StatusError,
+// which lets callers check a solution's status with errors.Is, as in
+// errors.Is(soln.Err(), ErrInfeasible).
+func (s *RawSolution) Err() error {
+	if s.Status == Optimal {
+		return nil
+	}
+	return StatusError{Status: s.Status}
+}
+
+// errDetached is returned by RawSolution methods that require the model that
+// produced the solution when invoked on a solution returned by Clone.
+var errDetached = errors.New("solution is not associated with a model")
+
+// Clone returns a deep copy of a solution that is not associated with the
+// model that produced it.  The copy therefore remains valid after the model
+// is modified or solved again.  Methods that query HiGHS, such as GetIntInfo,
+// WriteSolution, and Ranging, return an error when invoked on the copy.  The
+// exception is EffectiveOptions, which reports the options that were in effect
+// when the copy was made.
+func (s *RawSolution) Clone() *RawSolution {
+	c := *s
+	c.rm = nil
+	if opts, err := s.EffectiveOptions(); err == nil {
+		c.opts = &opts
+	}
+	c.ColumnPrimal = cloneSlice(s.ColumnPrimal)
+	c.RowPrimal = cloneSlice(s.RowPrimal)
+	c.ColumnDual = cloneSlice(s.ColumnDual)
+	c.RowDual = cloneSlice(s.RowDual)
+	c.ColumnBasis = cloneSlice(s.ColumnBasis)
+	c.RowBasis = cloneSlice(s.RowBasis)
+	return &c
+}
+
+// EffectiveOptions returns the values of a selection of key options of the
+// model that produced the solution.  The values are read when EffectiveOptions
+// is called, so they describe the solve only if the model's options have not
+// been changed since.  Options HiGHS resolves internally, such as
+// solver="choose", are reported as set, not as resolved.
+func (s *RawSolution) EffectiveOptions() (Options, error) {
+	if s.opts != nil {
+		return *s.opts, nil
+	}
+	if s.rm == nil {
+		return Options{}, errDetached
+	}
+	return s.rm.effectiveOptions()
 }
 
 // GetIntInfo returns the integer value of a named piece of information.
 func (s *RawSolution) GetIntInfo(info string) (int, error) {
+	if s.rm == nil {
+		return 0, errDetached
+	}
 	// Convert the info argument from Go to C.
 	str := C.CString(info)
 	defer C.free(unsafe.Pointer(str))
@@ -40,6 +133,9 @@ func (s *RawSolution) GetIntInfo(info string) (int, error) {
 // GetInt64Info returns the 64-bit integer value of a named piece of
 // information.
 func (s *RawSolution) GetInt64Info(info string) (int64, error) {
+	if s.rm == nil {
+		return 0, errDetached
+	}
 	// Convert the info argument from Go to C.
 	str := C.CString(info)
 	defer C.free(unsafe.Pointer(str))
@@ -57,6 +153,9 @@ func (s *RawSolution) GetInt64Info(info string) (int64, error) {
 // GetFloat64Info returns the floating-point value of a named piece of
 // information.
 func (s *RawSolution) GetFloat64Info(info string) (float64, error) {
+	if s.rm == nil {
+		return 0.0, errDetached
+	}
 	// Convert the info argument from Go to C.
 	str := C.CString(info)
 	defer C.free(unsafe.Pointer(str))
@@ -71,27 +170,215 @@ func (s *RawSolution) GetFloat64Info(info string) (float64, error) {
 	return float64(val), nil
 }
 
-// WriteSolutionToFile writes a textual version of the solution to a named
-// file.  If the second argument is false, WriteSolutiontoFile will use a more
-// computer-friendly format; if true, it will use a more human-friendly format.
-func (s *RawSolution) WriteSolutionToFile(fn string, pretty bool) error {
+// runTime returns the time in seconds that HiGHS has spent running the model.
+func (s *RawSolution) runTime() float64 {
+	if s.rm == nil {
+		return 0.0
+	}
+	return float64(C.Highs_getRunTime(s.rm.obj))
+}
+
+// DualRay returns a dual ray—a certificate of primal infeasibility with one
+// value per row—and true if HiGHS found one.  Dual rays are meaningful only
+// for models whose status is Infeasible or UnboundedOrInfeasible, so DualRay
+// returns nil, false, and a nil error for solutions with any other status.
+func (s *RawSolution) DualRay() ([]float64, bool, error) {
+	if s.Status != Infeasible && s.Status != UnboundedOrInfeasible {
+		return nil, false, nil
+	}
+	if s.rm == nil {
+		return nil, false, errDetached
+	}
+	n := s.rm.NumRows()
+	var hasRay C.HighsInt
+	ray := make([]C.double, n+1) // +1 to avoid taking the address of an empty slice
+	status := C.Highs_getDualRay(s.rm.obj, &hasRay, &ray[0])
+	err := s.rm.recordStatus(status, "Highs_getDualRay", "DualRay")
+	if err != nil || hasRay == 0 {
+		return nil, false, err
+	}
+	return convertSlice[float64, C.double](ray[:n]), true, nil
+}
+
+// PrimalRay returns a primal ray—a certificate of unboundedness with one
+// value per column—and true if HiGHS found one.  Primal rays are meaningful
+// only for models whose status is Unbounded or UnboundedOrInfeasible, so
+// PrimalRay returns nil, false, and a nil error for solutions with any other
+// status.
+func (s *RawSolution) PrimalRay() ([]float64, bool, error) {
+	if s.Status != Unbounded && s.Status != UnboundedOrInfeasible {
+		return nil, false, nil
+	}
+	if s.rm == nil {
+		return nil, false, errDetached
+	}
+	n := s.rm.NumCols()
+	var hasRay C.HighsInt
+	ray := make([]C.double, n+1) // +1 to avoid taking the address of an empty slice
+	status := C.Highs_getPrimalRay(s.rm.obj, &hasRay, &ray[0])
+	err := s.rm.recordStatus(status, "Highs_getPrimalRay", "PrimalRay")
+	if err != nil || hasRay == 0 {
+		return nil, false, err
+	}
+	return convertSlice[float64, C.double](ray[:n]), true, nil
+}
+
+// A RangingRecord describes, for each column or row, how far a cost or bound
+// can move in one direction before the optimal basis changes.
+type RangingRecord struct {
+	Value     []float64 // Cost or bound at which the basis changes
+	Objective []float64 // Objective value when the cost or bound equals Value
+	InVar     []int     // Variable that enters the basis at Value
+	OutVar    []int     // Variable that leaves the basis at Value
+}
+
+// Ranging holds the results of sensitivity analysis: the ranges over which
+// column costs, column bounds, and row bounds can increase or decrease
+// without changing the optimal basis.
+type Ranging struct {
+	ColCostUp    RangingRecord // Increasing each column's cost
+	ColCostDown  RangingRecord // Decreasing each column's cost
+	ColBoundUp   RangingRecord // Increasing each column's value
+	ColBoundDown RangingRecord // Decreasing each column's value
+	RowBoundUp   RangingRecord // Increasing each row's activity
+	RowBoundDown RangingRecord // Decreasing each row's activity
+}
+
+// cRangingRecord is the C equivalent of a RangingRecord.  Each slice holds
+// one more element than needed to avoid taking the address of an empty
+// slice.
+type cRangingRecord struct {
+	value, objective []C.double
+	inVar, outVar    []C.HighsInt
+}
+
+// newCRangingRecord allocates a cRangingRecord for n columns or rows.
+func newCRangingRecord(n int) cRangingRecord {
+	return cRangingRecord{
+		value:     make([]C.double, n+1),
+		objective: make([]C.double, n+1),
+		inVar:     make([]C.HighsInt, n+1),
+		outVar:    make([]C.HighsInt, n+1),
+	}
+}
+
+// toGo converts a cRangingRecord for n columns or rows to a RangingRecord.
+func (r cRangingRecord) toGo(n int) RangingRecord {
+	return RangingRecord{
+		Value:     convertSlice[float64, C.double](r.value[:n]),
+		Objective: convertSlice[float64, C.double](r.objective[:n]),
+		InVar:     convertSlice[int, C.HighsInt](r.inVar[:n]),
+		OutVar:    convertSlice[int, C.HighsInt](r.outVar[:n]),
+	}
+}
+
+// Ranging performs sensitivity analysis on an optimal solution of a linear
+// program.  HiGHS requires the solution to have an optimal basis, so
+// Ranging fails for mixed-integer models and for models solved without
+// crossover.
+func (s *RawSolution) Ranging() (*Ranging, error) {
+	if s.rm == nil {
+		return nil, errDetached
+	}
+	nc, nr := s.rm.NumCols(), s.rm.NumRows()
+	ccUp, ccDn := newCRangingRecord(nc), newCRangingRecord(nc)
+	cbUp, cbDn := newCRangingRecord(nc), newCRangingRecord(nc)
+	rbUp, rbDn := newCRangingRecord(nr), newCRangingRecord(nr)
+	status := C.Highs_getRanging(s.rm.obj,
+		&ccUp.value[0], &ccUp.objective[0], &ccUp.inVar[0], &ccUp.outVar[0],
+		&ccDn.value[0], &ccDn.objective[0], &ccDn.inVar[0], &ccDn.outVar[0],
+		&cbUp.value[0], &cbUp.objective[0], &cbUp.inVar[0], &cbUp.outVar[0],
+		&cbDn.value[0], &cbDn.objective[0], &cbDn.inVar[0], &cbDn.outVar[0],
+		&rbUp.value[0], &rbUp.objective[0], &rbUp.inVar[0], &rbUp.outVar[0],
+		&rbDn.value[0], &rbDn.objective[0], &rbDn.inVar[0], &rbDn.outVar[0])
+	err := s.rm.recordStatus(status, "Highs_getRanging", "Ranging")
+	if err != nil {
+		return nil, err
+	}
+	return &Ranging{
+		ColCostUp:    ccUp.toGo(nc),
+		ColCostDown:  ccDn.toGo(nc),
+		ColBoundUp:   cbUp.toGo(nc),
+		ColBoundDown: cbDn.toGo(nc),
+		RowBoundUp:   rbUp.toGo(nr),
+		RowBoundDown: rbDn.toGo(nr),
+	}, nil
+}
+
+// A SolutionStyle specifies the format in which a solution is written.
+type SolutionStyle int
+
+// These are the values a SolutionStyle accepts.  They correspond to HiGHS's
+// kSolutionStyle constants.  HiGHS's other styles, including its
+// glpsol-compatible styles, are not offered because HiGHS's C API cannot
+// write solutions in them.
+const (
+	SolutionStyleRaw    SolutionStyle = iota // Computer-friendly format
+	SolutionStylePretty                      // Human-friendly format
+)
+
+//go:generate stringer -type=SolutionStyle
+
+// writeSolutionFile writes a solution in a given style to a named file.
+func (s *RawSolution) writeSolutionFile(fn string, style SolutionStyle, gName string) error {
+	if s.rm == nil {
+		return errDetached
+	}
 	// Convert the filename argument from Go to C.
 	cFName := C.CString(fn)
 	defer C.free(unsafe.Pointer(cFName))
 
 	// Write the solution.
-	if pretty {
+	switch style {
+	case SolutionStyleRaw:
+		status := C.Highs_writeSolution(s.rm.obj, cFName)
+		return s.rm.recordStatus(status, "Highs_writeSolution", gName)
+	case SolutionStylePretty:
 		status := C.Highs_writeSolutionPretty(s.rm.obj, cFName)
-		return newCallStatus(status, "Highs_writeSolutionPretty", "WriteSolutionToFile")
+		return s.rm.recordStatus(status, "Highs_writeSolutionPretty", gName)
+	default:
+		return fmt.Errorf("%s: invalid solution style %d", gName, style)
+	}
+}
+
+// WriteSolutionToFile writes a textual version of the solution to a named
+// file.  If the second argument is false, WriteSolutiontoFile will use a more
+// computer-friendly format; if true, it will use a more human-friendly format.
+func (s *RawSolution) WriteSolutionToFile(fn string, pretty bool) error {
+	style := SolutionStyleRaw
+	if pretty {
+		style = SolutionStylePretty
 	}
-	status := C.Highs_writeSolution(s.rm.obj, cFName)
-	return newCallStatus(status, "Highs_writeSolution", "WriteSolutionToFile")
+	return s.writeSolutionFile(fn, style, "WriteSolutionToFile")
 }
 
 // WriteSolution writes a textual version of the solution to an io.Writer.  If
 // the second argument is false, WriteSolutiontoFile will use a more
 // computer-friendly format; if true, it will use a more human-friendly format.
 func (s *RawSolution) WriteSolution(w io.Writer, pretty bool) error {
+	style := SolutionStyleRaw
+	if pretty {
+		style = SolutionStylePretty
+	}
+	return s.writeSolution(w, style, "WriteSolution")
+}
+
+// WritePretty writes a human-readable report of the solution to an
+// io.Writer.  The report lists each column's and row's bounds, primal and
+// dual values, and basis status followed by the model status and objective
+// value.  WritePretty is equivalent to WriteSolution(w, true).
+func (s *RawSolution) WritePretty(w io.Writer) error {
+	return s.writeSolution(w, SolutionStylePretty, "WritePretty")
+}
+
+// WriteSolutionStyle writes a textual version of the solution to an
+// io.Writer in a given style.
+func (s *RawSolution) WriteSolutionStyle(w io.Writer, style SolutionStyle) error {
+	return s.writeSolution(w, style, "WriteSolutionStyle")
+}
+
+// writeSolution writes a solution in a given style to an io.Writer.
+func (s *RawSolution) writeSolution(w io.Writer, style SolutionStyle, gName string) error {
 	// Create a throwaway file to use as a staging area.
 	tFile, err := os.CreateTemp("", "highs-*.txt")
 	if err != nil {
@@ -104,18 +391,8 @@ func (s *RawSolution) WriteSolution(w io.Writer, pretty bool) error {
 		return err
 	}
 
-	// Convert the throwaway filename from Go to C.
-	cFName := C.CString(fName)
-	defer C.free(unsafe.Pointer(cFName))
-
 	// Write the solution to the throwaway file.
-	if pretty {
-		status := C.Highs_writeSolutionPretty(s.rm.obj, cFName)
-		err = newCallStatus(status, "Highs_writeSolutionPretty", "WriteSolution")
-	} else {
-		status := C.Highs_writeSolution(s.rm.obj, cFName)
-		err = newCallStatus(status, "Highs_writeSolution", "WriteSolution")
-	}
+	err = s.writeSolutionFile(fName, style, gName)
 	if err != nil {
 		return err
 	}
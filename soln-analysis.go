@@ -0,0 +1,417 @@
+// This file provides methods that post-process the values stored in a
+// RawSolution.
+
+package highs
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// boundTol is the absolute tolerance used when comparing a primal value to a
+// bound in the absence of basis information.
+const boundTol = 1e-9
+
+// infiniteBound is the magnitude at or above which HiGHS by default treats a
+// bound as infinite.
+const infiniteBound = 1e20
+
+// atBound reports whether a value equals a bound to within boundTol.
+// Infinite bounds are never reached.
+func atBound(v, b float64) bool {
+	if math.Abs(b) >= infiniteBound {
+		return false
+	}
+	return math.Abs(v-b) <= boundTol
+}
+
+// ActiveSet returns the indices of the binding rows and of the columns that
+// lie at one of their bounds.  Together, these characterize the optimal face.
+// ActiveSet relies on the basis status of each row and column when available
+// and otherwise compares the primal values against the bounds specified by
+// the given model.
+func (s *RawSolution) ActiveSet(m *Model) (rows []int, cols []int) {
+	// Use the basis if we have one.
+	if s.RowBasis != nil && s.ColumnBasis != nil {
+		for i, b := range s.RowBasis {
+			if b == Lower || b == Upper {
+				rows = append(rows, i)
+			}
+		}
+		for i, b := range s.ColumnBasis {
+			if b == Lower || b == Upper {
+				cols = append(cols, i)
+			}
+		}
+		return rows, cols
+	}
+
+	// Otherwise, compare values to bounds.
+	for i, v := range s.RowPrimal {
+		if (i < len(m.RowLower) && atBound(v, m.RowLower[i])) ||
+			(i < len(m.RowUpper) && atBound(v, m.RowUpper[i])) {
+			rows = append(rows, i)
+		}
+	}
+	for i, v := range s.ColumnPrimal {
+		if (i < len(m.ColLower) && atBound(v, m.ColLower[i])) ||
+			(i < len(m.ColUpper) && atBound(v, m.ColUpper[i])) {
+			cols = append(cols, i)
+		}
+	}
+	return rows, cols
+}
+
+// RoundedColumns returns a copy of the primal column values in which each
+// value that lies within tol of an integer is replaced by that integer.
+// Other values are returned unmodified.
+func (s *RawSolution) RoundedColumns(tol float64) []float64 {
+	rounded := make([]float64, len(s.ColumnPrimal))
+	for i, v := range s.ColumnPrimal {
+		r := math.Round(v)
+		if math.Abs(v-r) <= tol {
+			v = r
+		}
+		rounded[i] = v
+	}
+	return rounded
+}
+
+// DualFeasiblePerRow indicates, for each row, whether the row's dual value
+// has the sign required for dual feasibility, to within a tolerance.  For a
+// minimization problem, a row at its lower bound requires a nonnegative dual
+// value, a row at its upper bound requires a nonpositive dual value, and a
+// basic row requires a zero dual value; the signs are reversed for
+// maximization problems.  Equality rows can take dual values of either sign.
+// DualFeasiblePerRow returns nil if the solution lacks either dual values or
+// basis information.
+func (s *RawSolution) DualFeasiblePerRow(tol float64) []bool {
+	if s.RowDual == nil || s.RowBasis == nil || s.rm == nil {
+		return nil
+	}
+	maximize, err := s.rm.isMaximization()
+	if err != nil {
+		return nil
+	}
+	lower, upper, err := s.rm.rowBounds()
+	if err != nil || len(lower) != len(s.RowDual) {
+		return nil
+	}
+	feas := make([]bool, len(s.RowDual))
+	for r, y := range s.RowDual {
+		if maximize {
+			y = -y
+		}
+		switch {
+		case lower[r] == upper[r]:
+			feas[r] = true
+		case s.RowBasis[r] == Lower:
+			feas[r] = y >= -tol
+		case s.RowBasis[r] == Upper:
+			feas[r] = y <= tol
+		default:
+			feas[r] = math.Abs(y) <= tol
+		}
+	}
+	return feas
+}
+
+// VariablesAtBounds returns the indices of the columns whose primal values lie
+// within tol of their lower bounds and of those whose primal values lie
+// within tol of their upper bounds, as specified by the given model.  A fixed
+// column appears in both lists.
+func (s *RawSolution) VariablesAtBounds(m *Model, tol float64) (atLower, atUpper []int) {
+	_, colLower, colUpper, _, _, err := m.denseVectors()
+	if err != nil {
+		return nil, nil
+	}
+	for c, v := range s.ColumnPrimal {
+		if c >= len(colLower) {
+			break
+		}
+		if math.Abs(colLower[c]) < infiniteBound && math.Abs(v-colLower[c]) <= tol {
+			atLower = append(atLower, c)
+		}
+		if math.Abs(colUpper[c]) < infiniteBound && math.Abs(v-colUpper[c]) <= tol {
+			atUpper = append(atUpper, c)
+		}
+	}
+	return atLower, atUpper
+}
+
+// formatPrometheusValue formats a floating-point value as required by the
+// Prometheus text exposition format.
+func formatPrometheusValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+// validMetricName reports whether a string satisfies the Prometheus
+// metric-name grammar, [a-zA-Z_:][a-zA-Z0-9_:]*.
+func validMetricName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, ch := range name {
+		switch {
+		case ch >= 'a' && ch <= 'z', ch >= 'A' && ch <= 'Z', ch == '_', ch == ':':
+		case ch >= '0' && ch <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// PrometheusMetrics returns a set of solver performance metrics in the
+// Prometheus text exposition format.  Each metric is reported as a gauge and
+// comprises a "# TYPE name gauge" line followed by a "name value" line.  The
+// metrics comprise the objective value, simplex and interior-point iteration
+// counts, the MIP node count, the solve time, and the MIP gap.  Each metric
+// name begins with the given prefix followed by an underscore.  An error is
+// returned if the prefix is neither empty nor a valid metric name.  Metrics
+// that HiGHS cannot provide, including negative counts, are omitted.
+func (s *RawSolution) PrometheusMetrics(prefix string) ([]string, error) {
+	if prefix != "" {
+		if !validMetricName(prefix) {
+			return nil, fmt.Errorf("%q is not a valid Prometheus metric name", prefix)
+		}
+		prefix += "_"
+	}
+	lines := make([]string, 0, 12)
+	add := func(name string, v float64) {
+		name = prefix + name
+		lines = append(lines, "# TYPE "+name+" gauge", name+" "+formatPrometheusValue(v))
+	}
+	add("objective", s.Objective)
+	if s.SimplexIterations >= 0 {
+		add("simplex_iterations", float64(s.SimplexIterations))
+	}
+	if s.IpmIterations >= 0 {
+		add("ipm_iterations", float64(s.IpmIterations))
+	}
+	if s.MipNodes >= 0 {
+		add("mip_nodes", float64(s.MipNodes))
+	}
+	add("solve_time_seconds", s.runTime())
+	if g, err := s.GetFloat64Info("mip_gap"); err == nil {
+		add("mip_gap", g)
+	}
+	return lines, nil
+}
+
+// A VariableRecord gathers into a single value all of the information about
+// one column (variable) in a solved model.
+type VariableRecord struct {
+	Name        string      // Column name
+	Value       float64     // Primal value
+	ReducedCost float64     // Dual value (0 if unavailable)
+	Lower       float64     // Lower bound
+	Upper       float64     // Upper bound
+	Basis       BasisStatus // Basis status (UnknownBasisStatus if unavailable)
+}
+
+// Records joins a solution's per-column values with the names and bounds
+// specified by the given model, returning one VariableRecord per column.
+// Columns lacking a name in the model's ColNames are named "c" followed by
+// the column number, as in HiGHS's own output.  Records returns nil if the
+// model and solution disagree on the number of columns.
+func (s *RawSolution) Records(m *Model) []VariableRecord {
+	_, colLower, colUpper, _, _, err := m.denseVectors()
+	if err != nil || len(colLower) != len(s.ColumnPrimal) {
+		return nil
+	}
+	recs := make([]VariableRecord, len(s.ColumnPrimal))
+	for c, v := range s.ColumnPrimal {
+		r := VariableRecord{
+			Name:  m.colName(c),
+			Value: v,
+			Lower: colLower[c],
+			Upper: colUpper[c],
+		}
+		if c < len(s.ColumnDual) {
+			r.ReducedCost = s.ColumnDual[c]
+		}
+		if c < len(s.ColumnBasis) {
+			r.Basis = s.ColumnBasis[c]
+		}
+		recs[c] = r
+	}
+	return recs
+}
+
+// IsIntegerFeasible reports whether every integer, semi-integer, and
+// implicit-integer column of the given model has a value in the solution that
+// lies within tol of an integer.  This is useful for determining whether the
+// solution to a model's continuous relaxation also solves the model itself.
+func (s *RawSolution) IsIntegerFeasible(m *Model, tol float64) bool {
+	for c, vt := range m.VarTypes {
+		switch vt {
+		case IntegerType, SemiIntegerType, ImplicitIntegerType:
+		default:
+			continue
+		}
+		if c >= len(s.ColumnPrimal) {
+			return false
+		}
+		v := s.ColumnPrimal[c]
+		if math.Abs(v-math.Round(v)) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+// IsVertex reports whether the solution is a basic (vertex) solution, as
+// produced by the simplex solver or by the interior-point solver followed by
+// crossover, rather than an interior point.  Only for vertex solutions do the
+// basis and reduced costs have their usual meaning.  IsVertex checks that
+// every row and column has a known basis status and that the number of basic
+// rows and columns equals the number of rows.
+func (s *RawSolution) IsVertex() bool {
+	if len(s.ColumnBasis) != len(s.ColumnPrimal) || len(s.RowBasis) != len(s.RowPrimal) {
+		return false
+	}
+	if s.ColumnBasis == nil && s.RowBasis == nil {
+		return false
+	}
+	nBasic := 0
+	for _, bs := range [][]BasisStatus{s.ColumnBasis, s.RowBasis} {
+		for _, b := range bs {
+			switch b {
+			case UnknownBasisStatus:
+				return false
+			case Basic:
+				nBasic++
+			}
+		}
+	}
+	return nBasic == len(s.RowBasis)
+}
+
+// DualContributions returns each row's contribution to the objective value,
+// indexed by row and computed as the row's dual value times the bound at
+// which the row is active.  By strong duality, the contributions of a linear program's rows sum
+// to the objective value minus the offset and minus the contributions of any
+// columns held at a bound by a nonzero reduced cost.  The active bound is
+// determined from the row's basis status when available and otherwise is
+// whichever finite bound, as specified by the given model, lies closer to
+// the row's primal value.  DualContributions returns nil if the solution
+// lacks dual values.
+func (s *RawSolution) DualContributions(m *Model) []float64 {
+	_, _, _, rowLower, rowUpper, err := m.denseVectors()
+	if err != nil || s.RowDual == nil || len(rowLower) != len(s.RowDual) {
+		return nil
+	}
+	contrib := make([]float64, len(s.RowDual))
+	for r, y := range s.RowDual {
+		lo, up := rowLower[r], rowUpper[r]
+		var rhs float64
+		switch {
+		case y == 0.0:
+			rhs = 0.0
+		case r < len(s.RowBasis) && s.RowBasis[r] == Lower:
+			rhs = lo
+		case r < len(s.RowBasis) && s.RowBasis[r] == Upper:
+			rhs = up
+		case math.Abs(lo) >= infiniteBound:
+			rhs = up
+		case math.Abs(up) >= infiniteBound:
+			rhs = lo
+		case r < len(s.RowPrimal) && math.Abs(s.RowPrimal[r]-up) < math.Abs(s.RowPrimal[r]-lo):
+			rhs = up
+		default:
+			rhs = lo
+		}
+		contrib[r] = y * rhs
+	}
+	return contrib
+}
+
+// FractionalIntegers returns the indices of the integer, semi-integer, and
+// implicit-integer columns of the given model whose values in the solution lie
+// farther than tol from an integer.  When applied to a solution of a model's
+// continuous relaxation, these are the candidates for branching.
+func (s *RawSolution) FractionalIntegers(m *Model, tol float64) []int {
+	var frac []int
+	for c, vt := range m.VarTypes {
+		switch vt {
+		case IntegerType, SemiIntegerType, ImplicitIntegerType:
+		default:
+			continue
+		}
+		if c >= len(s.ColumnPrimal) {
+			break
+		}
+		v := s.ColumnPrimal[c]
+		if math.Abs(v-math.Round(v)) > tol {
+			frac = append(frac, c)
+		}
+	}
+	return frac
+}
+
+// SparsePrimal returns the primal column values whose magnitudes exceed tol,
+// keyed by column index.  For solutions in which most columns are zero, this
+// is far more compact than ColumnPrimal.
+func (s *RawSolution) SparsePrimal(tol float64) map[int]float64 {
+	sparse := make(map[int]float64)
+	for c, v := range s.ColumnPrimal {
+		if math.Abs(v) > tol {
+			sparse[c] = v
+		}
+	}
+	return sparse
+}
+
+// verify confirms, to within an absolute tolerance, that a solution of a
+// model satisfies the model's bounds and integrality requirements and, to
+// within a relative tolerance, that its objective value agrees with the value
+// recomputed from its column values.  It returns an error describing the
+// first discrepancy it finds.
+func (s *RawSolution) verify(m *Model, tol float64) error {
+	obj, act, _, err := m.Evaluate(s.ColumnPrimal)
+	if err != nil {
+		return err
+	}
+	if math.Abs(obj-s.Objective) > tol*math.Max(1.0, math.Abs(obj)) {
+		return fmt.Errorf("reported objective value %v differs from recomputed value %v",
+			s.Objective, obj)
+	}
+	_, colLower, colUpper, rowLower, rowUpper, err := m.denseVectors()
+	if err != nil {
+		return err
+	}
+	for r, v := range act {
+		if v < rowLower[r]-tol || v > rowUpper[r]+tol {
+			return fmt.Errorf("row %d has activity %v outside [%v, %v]",
+				r, v, rowLower[r], rowUpper[r])
+		}
+	}
+	for c, v := range s.ColumnPrimal {
+		vt := ContinuousType
+		if c < len(m.VarTypes) {
+			vt = m.VarTypes[c]
+		}
+		isZero := math.Abs(v) <= tol
+		switch {
+		case (vt == SemiContinuousType || vt == SemiIntegerType) && isZero:
+			continue
+		case v < colLower[c]-tol || v > colUpper[c]+tol:
+			return fmt.Errorf("column %d has value %v outside [%v, %v]",
+				c, v, colLower[c], colUpper[c])
+		case vt != ContinuousType && vt != SemiContinuousType && math.Abs(v-math.Round(v)) > tol:
+			return fmt.Errorf("column %d has non-integral value %v", c, v)
+		}
+	}
+	return nil
+}
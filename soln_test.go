@@ -5,6 +5,8 @@ package highs
 import (
 	"bytes"
 	"errors"
+	"math"
+	"strings"
 	"testing"
 )
 
@@ -193,3 +195,475 @@ Objective value: 23
 		t.Fatal("textual solution was not as expected")
 	}
 }
+
+// TestWritePretty tests that WritePretty produces a human-readable report
+// that includes the objective value.
+func TestWritePretty(t *testing.T) {
+	soln, err := modelAndSolve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	checkErr(t, soln.WritePretty(&buf))
+	if !strings.Contains(buf.String(), "Objective value: 23") {
+		t.Fatalf("expected the report to mention the objective value but saw %q", buf.String())
+	}
+}
+
+// TestWriteSolutionStyle tests that each solution style produces distinct
+// output and that an invalid style is rejected.
+func TestWriteSolutionStyle(t *testing.T) {
+	// Produce a solution.
+	soln, err := modelAndSolve()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Write the solution in each style.
+	seen := make(map[string]SolutionStyle)
+	for style := SolutionStyleRaw; style <= SolutionStylePretty; style++ {
+		var buf bytes.Buffer
+		checkErr(t, soln.WriteSolutionStyle(&buf, style))
+		if prev, ok := seen[buf.String()]; ok {
+			t.Fatalf("styles %v and %v produced identical output", prev, style)
+		}
+		seen[buf.String()] = style
+	}
+
+	// Ensure that an invalid style is rejected.
+	var buf bytes.Buffer
+	if err := soln.WriteSolutionStyle(&buf, SolutionStyle(2)); err == nil {
+		t.Fatal("expected WriteSolutionStyle to reject an invalid style")
+	}
+}
+
+// TestActiveSet tests that ActiveSet reports the binding rows and the columns
+// at their bounds for the maximization variant of the minimal API model.
+func TestActiveSet(t *testing.T) {
+	model := minimalAPIModel(true)
+	soln := solveRaw(t, model)
+	rows, cols := soln.ActiveSet(model)
+	compSlices(t, "rows", rows, []int{1})
+	compSlices(t, "cols", cols, []int{0})
+
+	// Repeat the test without basis information.
+	soln.RowBasis = nil
+	soln.ColumnBasis = nil
+	rows, cols = soln.ActiveSet(model)
+	compSlices(t, "rows", rows, []int{1})
+	compSlices(t, "cols", cols, []int{0})
+}
+
+// TestRoundedColumns tests that RoundedColumns snaps near-integral values to
+// integers while leaving other values alone.
+func TestRoundedColumns(t *testing.T) {
+	var soln RawSolution
+	soln.ColumnPrimal = []float64{2.9999999, 1.5, -4.0000001, 7.0}
+	compSlices(t, "RoundedColumns", soln.RoundedColumns(1e-6),
+		[]float64{3.0, 1.5, -4.0, 7.0})
+	compSlices(t, "ColumnPrimal", soln.ColumnPrimal,
+		[]float64{2.9999999, 1.5, -4.0000001, 7.0})
+}
+
+// TestDualFeasiblePerRow tests that DualFeasiblePerRow reports all rows as
+// dual feasible for cleanly solved minimization and maximization problems.
+func TestDualFeasiblePerRow(t *testing.T) {
+	for _, maximize := range []bool{false, true} {
+		soln := solveRaw(t, minimalAPIModel(maximize))
+		feas := soln.DualFeasiblePerRow(1e-9)
+		if len(feas) != 3 {
+			t.Fatalf("expected 3 values but saw %v", feas)
+		}
+		for r, f := range feas {
+			if !f {
+				t.Fatalf("row %d was reported as dual infeasible", r)
+			}
+		}
+	}
+}
+
+// TestVariablesAtBounds tests that VariablesAtBounds finds the variable at its
+// upper bound in the maximization variant of the minimal API model.
+func TestVariablesAtBounds(t *testing.T) {
+	model := minimalAPIModel(true)
+	soln := solveRaw(t, model)
+	atLower, atUpper := soln.VariablesAtBounds(model, 1e-9)
+	compSlices(t, "atLower", atLower, []int{})
+	compSlices(t, "atUpper", atUpper, []int{0})
+}
+
+// TestPrometheusMetrics tests that PrometheusMetrics reports a solve time and
+// the solution's iteration counts, declares each metric's type, and rejects
+// invalid prefixes.
+func TestPrometheusMetrics(t *testing.T) {
+	soln, err := modelAndSolve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	soln.SimplexIterations = 7
+	soln.IpmIterations = -1
+	lines, err := soln.PrometheusMetrics("highs")
+	checkErr(t, err)
+	metrics := make(map[string]string)
+	typed := make(map[string]bool)
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 4 && fields[0] == "#" && fields[1] == "TYPE" && fields[3] == "gauge":
+			typed[fields[2]] = true
+		case len(fields) == 2:
+			if !typed[fields[0]] {
+				t.Fatalf("metric %s lacks a preceding TYPE line", fields[0])
+			}
+			metrics[fields[0]] = fields[1]
+		default:
+			t.Fatalf("malformed metric line %q", line)
+		}
+	}
+	if _, ok := metrics["highs_solve_time_seconds"]; !ok {
+		t.Fatal("no highs_solve_time_seconds metric was reported")
+	}
+	if v := metrics["highs_simplex_iterations"]; v != "7" {
+		t.Fatalf("expected 7 simplex iterations but saw %q", v)
+	}
+	if v, ok := metrics["highs_ipm_iterations"]; ok {
+		t.Fatalf("expected a negative IPM iteration count to be omitted but saw %q", v)
+	}
+
+	// Invalid prefixes are rejected.
+	for _, prefix := range []string{"0highs", "highs-solver", "highs solver"} {
+		if _, err := soln.PrometheusMetrics(prefix); err == nil {
+			t.Fatalf("expected prefix %q to be rejected", prefix)
+		}
+	}
+}
+
+// TestRecords tests that Records joins the solution to the
+// TestMinimalAPIMax model with the model's names and bounds.
+func TestRecords(t *testing.T) {
+	model := minimalAPIModel(true)
+	model.ColNames = []string{"x", "y"}
+	soln := solveRaw(t, model)
+	recs := soln.Records(model)
+	if len(recs) != 2 {
+		t.Fatalf("expected 2 records but saw %d", len(recs))
+	}
+	rec := recs[0]
+	rec.Value = math.Round(rec.Value*1e6) / 1e6
+	rec.ReducedCost = math.Round(rec.ReducedCost*1e6) / 1e6
+	expected := VariableRecord{
+		Name:        "x",
+		Value:       4.0,
+		ReducedCost: 0.5,
+		Lower:       0.0,
+		Upper:       4.0,
+		Basis:       Upper,
+	}
+	if rec != expected {
+		t.Fatalf("expected %+v but saw %+v", expected, rec)
+	}
+	if recs[1].Name != "y" || recs[1].Basis != Basic {
+		t.Fatalf("unexpected second record %+v", recs[1])
+	}
+}
+
+// TestIsIntegerFeasible tests that IsIntegerFeasible accepts an integral
+// solution to a continuous relaxation and rejects a fractional one.
+func TestIsIntegerFeasible(t *testing.T) {
+	// The relaxation of this model has an integral optimum.
+	var model Model
+	model.Maximize = true
+	model.ColCosts = []float64{2.0, 1.0}
+	model.ColLower = []float64{0.0, 0.0}
+	model.ColUpper = []float64{2.0, 2.0}
+	model.VarTypes = []VariableType{IntegerType, IntegerType}
+	model.AddDenseRow(math.Inf(-1), []float64{1.0, 1.0}, 3.0)
+	relax := model
+	relax.VarTypes = nil
+	soln := solveRaw(t, &relax)
+	if !soln.IsIntegerFeasible(&model, 1e-6) {
+		t.Fatalf("IsIntegerFeasible rejected the integral solution %v", soln.ColumnPrimal)
+	}
+
+	// The relaxation of the TestMinimalAPIMax model does not.
+	model = *minimalAPIModel(true)
+	soln = solveRaw(t, &model)
+	model.VarTypes = []VariableType{IntegerType, IntegerType}
+	if soln.IsIntegerFeasible(&model, 1e-6) {
+		t.Fatalf("IsIntegerFeasible accepted the fractional solution %v", soln.ColumnPrimal)
+	}
+}
+
+// TestEffectiveOptions tests that a solution reports the solver that was
+// requested and that a clone retains the options after the model changes.
+func TestEffectiveOptions(t *testing.T) {
+	raw := mustToRawModel(t, minimalAPIModel(false))
+	checkErr(t, raw.SetStringOption("solver", "ipm"))
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts, err := soln.EffectiveOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Solver != "ipm" {
+		t.Fatalf("expected solver \"ipm\" but saw %q", opts.Solver)
+	}
+	if opts.PrimalFeasibilityTolerance <= 0.0 {
+		t.Fatalf("expected a positive primal feasibility tolerance but saw %v",
+			opts.PrimalFeasibilityTolerance)
+	}
+
+	// A clone reports the options in effect when it was made.
+	clone := soln.Clone()
+	checkErr(t, raw.SetStringOption("solver", "simplex"))
+	opts, err = clone.EffectiveOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Solver != "ipm" {
+		t.Fatalf("expected the clone to report solver \"ipm\" but saw %q", opts.Solver)
+	}
+}
+
+// TestIsVertex tests that IsVertex accepts an interior-point solution with
+// crossover and rejects one without.
+func TestIsVertex(t *testing.T) {
+	for _, crossover := range []string{"on", "off"} {
+		raw := mustToRawModel(t, minimalAPIModel(false))
+		checkErr(t, raw.SetStringOption("solver", "ipm"))
+		checkErr(t, raw.SetStringOption("run_crossover", crossover))
+		soln, err := raw.Solve()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if soln.IsVertex() != (crossover == "on") {
+			t.Fatalf("IsVertex returned %v with run_crossover=%s",
+				soln.IsVertex(), crossover)
+		}
+	}
+}
+
+// TestDualContributions tests that the per-row contributions to the objective
+// value of the TestMinimalAPIMin model sum to the objective value minus the
+// offset.
+func TestDualContributions(t *testing.T) {
+	model := minimalAPIModel(false)
+	soln := solveRaw(t, model)
+	contrib := soln.DualContributions(model)
+	if len(contrib) != 3 {
+		t.Fatalf("expected 3 contributions but saw %v", contrib)
+	}
+	sum := 0.0
+	for _, v := range contrib {
+		sum += v
+	}
+	compSlices(t, "DualContributions", roundFloats(1e-6, contrib), []float64{0.0, 1.25, 1.5})
+	if math.Abs(sum-(soln.Objective-model.Offset)) > 1e-9 {
+		t.Fatalf("contributions %v sum to %v, not %v", contrib, sum, soln.Objective-model.Offset)
+	}
+}
+
+// TestFractionalIntegers tests that FractionalIntegers identifies the one
+// fractional column in the relaxation of the TestMinimalAPIMax model.
+func TestFractionalIntegers(t *testing.T) {
+	model := *minimalAPIModel(true)
+	soln := solveRaw(t, &model)
+	model.VarTypes = []VariableType{IntegerType, IntegerType}
+	compSlices(t, "FractionalIntegers", soln.FractionalIntegers(&model, 1e-6), []int{1})
+
+	// Continuous columns are never reported.
+	model.VarTypes = []VariableType{IntegerType, ContinuousType}
+	compSlices(t, "FractionalIntegers", soln.FractionalIntegers(&model, 1e-6), []int{})
+}
+
+// TestSparsePrimal tests that SparsePrimal keeps nonzero column values and
+// omits zero and near-zero ones.
+func TestSparsePrimal(t *testing.T) {
+	var soln RawSolution
+	soln.ColumnPrimal = []float64{0.0, 1.0, 1e-12, 0.0, -2.5}
+	sparse := soln.SparsePrimal(1e-9)
+	if len(sparse) != 2 || sparse[1] != 1.0 || sparse[4] != -2.5 {
+		t.Fatalf("unexpected sparse primal values %v", sparse)
+	}
+}
+
+// TestRays tests that DualRay returns a certificate of infeasibility and that
+// rays are not returned for solutions with inapplicable statuses.
+func TestRays(t *testing.T) {
+	// Solve an infeasible model.  Disable presolve so that simplex
+	// detects the infeasibility and produces a dual ray.
+	var model Model
+	model.ColLower = []float64{0.0, 0.0}
+	model.ColUpper = []float64{10.0, 10.0}
+	model.AddDenseRow(4.0, []float64{1.0, 1.0}, math.Inf(1))
+	model.AddDenseRow(math.Inf(-1), []float64{1.0, 1.0}, 2.0)
+	raw := mustToRawModel(t, &model)
+	checkErr(t, raw.SetStringOption("presolve", "off"))
+	soln, err := raw.Solve()
+	checkErr(t, err)
+	if soln.Status != Infeasible {
+		t.Fatalf("expected status Infeasible but saw %v", soln.Status)
+	}
+	ray, ok, err := soln.DualRay()
+	checkErr(t, err)
+	if !ok {
+		t.Fatal("expected a dual ray for an infeasible model")
+	}
+	if len(ray) != 2 || ray[0] == 0.0 || ray[1] == 0.0 {
+		t.Fatalf("expected a dual ray involving both rows but saw %v", ray)
+	}
+	ray, ok, err = soln.PrimalRay()
+	if ray != nil || ok || err != nil {
+		t.Fatalf("expected no primal ray but saw (%v, %v, %v)", ray, ok, err)
+	}
+
+	// An optimal solution has no rays.
+	soln = solveRaw(t, minimalAPIModel(false))
+	ray, ok, err = soln.DualRay()
+	if ray != nil || ok || err != nil {
+		t.Fatalf("expected no dual ray but saw (%v, %v, %v)", ray, ok, err)
+	}
+}
+
+// TestRanging tests that Ranging returns plausible sensitivity information.
+func TestRanging(t *testing.T) {
+	soln := solveRaw(t, minimalAPIModel(false))
+	rng, err := soln.Ranging()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rec := range []RangingRecord{rng.ColCostUp, rng.ColCostDown, rng.ColBoundUp, rng.ColBoundDown} {
+		if len(rec.Value) != 2 || len(rec.Objective) != 2 || len(rec.InVar) != 2 || len(rec.OutVar) != 2 {
+			t.Fatalf("expected 2 column values but saw %v", rec)
+		}
+	}
+	for _, rec := range []RangingRecord{rng.RowBoundUp, rng.RowBoundDown} {
+		if len(rec.Value) != 3 || len(rec.Objective) != 3 || len(rec.InVar) != 3 || len(rec.OutVar) != 3 {
+			t.Fatalf("expected 3 row values but saw %v", rec)
+		}
+	}
+
+	// Row 1 (5 <= x0 + 2*x1) is binding, so its lower bound should be
+	// able to move in both directions from 5.
+	if up, dn := rng.RowBoundUp.Value[1], rng.RowBoundDown.Value[1]; up < 5.0 || dn > 5.0 {
+		t.Fatalf("expected row 1's range to contain 5 but saw [%v, %v]", dn, up)
+	}
+
+	// Each column's cost range should contain its current cost of 1.
+	for c := 0; c < 2; c++ {
+		if up, dn := rng.ColCostUp.Value[c], rng.ColCostDown.Value[c]; up < 1.0 || dn > 1.0 {
+			t.Fatalf("expected column %d's cost range to contain 1 but saw [%v, %v]", c, dn, up)
+		}
+	}
+}
+
+// TestSolveVerified tests that SolveVerified returns verified solutions and
+// that verification detects inconsistent solutions.
+func TestSolveVerified(t *testing.T) {
+	model := minimalAPIModel(false)
+	soln, err := model.SolveVerified(1e-6)
+	checkErr(t, err)
+	if math.Abs(soln.Objective-5.75) > 1e-6 {
+		t.Fatalf("expected objective 5.75 but saw %v", soln.Objective)
+	}
+
+	// The objective value is compared with a relative tolerance.
+	soln.Objective += 3e-6
+	if err := soln.verify(model, 1e-6); err != nil {
+		t.Fatalf("verify rejected an objective value within the relative tolerance (%v)", err)
+	}
+	soln.Objective -= 3e-6
+
+	// Tamper with the solution and ensure verification fails.
+	soln.Objective += 1.0
+	if soln.verify(model, 1e-6) == nil {
+		t.Fatal("verify accepted an incorrect objective value")
+	}
+	soln.Objective -= 1.0
+	soln.ColumnPrimal[1] = 0.0
+	if soln.verify(model, 1e-6) == nil {
+		t.Fatal("verify accepted an infeasible point")
+	}
+}
+
+// TestIterationCounts tests that a RawSolution records the work HiGHS
+// performed.
+func TestIterationCounts(t *testing.T) {
+	raw := mustToRawModel(t, minimalAPIModel(false))
+	checkErr(t, raw.SetStringOption("presolve", "off"))
+	checkErr(t, raw.SetStringOption("solver", "simplex"))
+	soln, err := raw.Solve()
+	checkErr(t, err)
+	if soln.SimplexIterations <= 0 {
+		t.Fatalf("expected a positive simplex iteration count but saw %d", soln.SimplexIterations)
+	}
+	if soln.IpmIterations != 0 || soln.MipNodes != 0 {
+		t.Fatalf("expected no IPM iterations or MIP nodes but saw %d and %d",
+			soln.IpmIterations, soln.MipNodes)
+	}
+}
+
+// TestSolutionErr tests that RawSolution.Err classifies a solution's status.
+func TestSolutionErr(t *testing.T) {
+	// An optimal solution has no error.
+	soln := solveRaw(t, minimalAPIModel(false))
+	if err := soln.Err(); err != nil {
+		t.Fatalf("expected no error but saw %v", err)
+	}
+
+	// An infeasible solution matches ErrInfeasible.
+	var model Model
+	model.ColLower = []float64{0.0}
+	model.AddDenseRow(math.Inf(-1), []float64{1.0}, -1.0)
+	raw := mustToRawModel(t, &model)
+	soln, err := raw.Solve()
+	checkErr(t, err)
+	err = soln.Err()
+	if !errors.Is(err, ErrInfeasible) || !errors.Is(err, ErrNotOptimal) {
+		t.Fatalf("expected ErrInfeasible and ErrNotOptimal but saw %v", err)
+	}
+	if errors.Is(err, ErrUnbounded) {
+		t.Fatal("an infeasible solution incorrectly matched ErrUnbounded")
+	}
+
+	// An ambiguous status matches both ErrInfeasible and ErrUnbounded.
+	err = StatusError{Status: UnboundedOrInfeasible}
+	if !errors.Is(err, ErrInfeasible) || !errors.Is(err, ErrUnbounded) {
+		t.Fatalf("expected %v to match ErrInfeasible and ErrUnbounded", err)
+	}
+}
+
+// TestClone tests that a cloned solution is independent of the original.
+func TestClone(t *testing.T) {
+	soln, err := modelAndSolve()
+	checkErr(t, err)
+	clone := soln.Clone()
+	want := append([]float64(nil), soln.ColumnPrimal...)
+	for i := range soln.ColumnPrimal {
+		soln.ColumnPrimal[i] = -1.0
+	}
+	soln.RowDual[0] = -1.0
+	compSlices(t, "ColumnPrimal", clone.ColumnPrimal, want)
+	if clone.RowDual[0] == -1.0 {
+		t.Fatal("modifying the original's row duals modified the clone's")
+	}
+	if clone.Objective != soln.Objective || clone.Status != soln.Status {
+		t.Fatal("the clone's objective or status differs from the original's")
+	}
+
+	// A clone cannot query HiGHS.
+	if _, err := clone.GetIntInfo("simplex_iteration_count"); err == nil {
+		t.Fatal("expected a detached clone's GetIntInfo to fail")
+	}
+
+	// A clone of an optimal solution has no rays, which requires no
+	// access to HiGHS.
+	if ray, ok, err := clone.DualRay(); ray != nil || ok || err != nil {
+		t.Fatalf("expected no dual ray and no error but saw %v, %v, %v", ray, ok, err)
+	}
+	if ray, ok, err := clone.PrimalRay(); ray != nil || ok || err != nil {
+		t.Fatalf("expected no primal ray and no error but saw %v, %v, %v", ray, ok, err)
+	}
+}
@@ -2,6 +2,8 @@
 
 package highs
 
+import "fmt"
+
 // #include "highs-externs.h"
 import "C"
 
@@ -44,12 +46,32 @@ func convertHighsBasisStatus(hbs C.HighsInt) BasisStatus {
 	}
 }
 
+// basisStatusToHighs converts a BasisStatus to a kHighsBasisStatus.
+func basisStatusToHighs(bs BasisStatus) (C.HighsInt, error) {
+	switch bs {
+	case Lower:
+		return C.kHighsBasisStatusLower, nil
+	case Basic:
+		return C.kHighsBasisStatusBasic, nil
+	case Upper:
+		return C.kHighsBasisStatusUpper, nil
+	case Zero:
+		return C.kHighsBasisStatusZero, nil
+	case NonBasic:
+		return C.kHighsBasisStatusNonbasic, nil
+	default:
+		return 0, fmt.Errorf("invalid basis status %v", bs)
+	}
+}
+
 //go:generate stringer -type=BasisStatus
 
 // A ModelStatus represents the status of an attempt to solve a model.
 type ModelStatus int
 
-// These are the values a ModelStatus accepts:
+// These are the values a ModelStatus accepts.  UnknownModelStatus indicates
+// a status that the highs package does not recognize, while Unknown
+// indicates that HiGHS itself could not determine the model's status.
 const (
 	UnknownModelStatus ModelStatus = iota
 	NotSet
@@ -67,44 +89,40 @@ const (
 	ObjectiveTarget
 	TimeLimit
 	IterationLimit
+	Unknown
+	SolutionLimit
+	Interrupt
 )
 
+// highsModelStatuses maps each kHighsModelStatus to a ModelStatus.  This map
+// must be kept up to date with the ModelStatus constants.
+var highsModelStatuses = map[C.HighsInt]ModelStatus{
+	C.kHighsModelStatusNotset:                NotSet,
+	C.kHighsModelStatusLoadError:             LoadError,
+	C.kHighsModelStatusModelError:            ModelError,
+	C.kHighsModelStatusPresolveError:         PresolveError,
+	C.kHighsModelStatusSolveError:            SolveError,
+	C.kHighsModelStatusPostsolveError:        PostsolveError,
+	C.kHighsModelStatusModelEmpty:            ModelEmpty,
+	C.kHighsModelStatusOptimal:               Optimal,
+	C.kHighsModelStatusInfeasible:            Infeasible,
+	C.kHighsModelStatusUnboundedOrInfeasible: UnboundedOrInfeasible,
+	C.kHighsModelStatusUnbounded:             Unbounded,
+	C.kHighsModelStatusObjectiveBound:        ObjectiveBound,
+	C.kHighsModelStatusObjectiveTarget:       ObjectiveTarget,
+	C.kHighsModelStatusTimeLimit:             TimeLimit,
+	C.kHighsModelStatusIterationLimit:        IterationLimit,
+	C.kHighsModelStatusUnknown:               Unknown,
+	C.kHighsModelStatusSolutionLimit:         SolutionLimit,
+	C.kHighsModelStatusInterrupt:             Interrupt,
+}
+
 // convertHighsModelStatus converts a kHighsModelStatus to a ModelStatus.
 func convertHighsModelStatus(hms C.HighsInt) ModelStatus {
-	switch hms {
-	case C.kHighsModelStatusNotset:
-		return NotSet
-	case C.kHighsModelStatusLoadError:
-		return LoadError
-	case C.kHighsModelStatusModelError:
-		return ModelError
-	case C.kHighsModelStatusPresolveError:
-		return PresolveError
-	case C.kHighsModelStatusSolveError:
-		return SolveError
-	case C.kHighsModelStatusPostsolveError:
-		return PostsolveError
-	case C.kHighsModelStatusModelEmpty:
-		return ModelEmpty
-	case C.kHighsModelStatusOptimal:
-		return Optimal
-	case C.kHighsModelStatusInfeasible:
-		return Infeasible
-	case C.kHighsModelStatusUnboundedOrInfeasible:
-		return UnboundedOrInfeasible
-	case C.kHighsModelStatusUnbounded:
-		return Unbounded
-	case C.kHighsModelStatusObjectiveBound:
-		return ObjectiveBound
-	case C.kHighsModelStatusObjectiveTarget:
-		return ObjectiveTarget
-	case C.kHighsModelStatusTimeLimit:
-		return TimeLimit
-	case C.kHighsModelStatusIterationLimit:
-		return IterationLimit
-	default:
-		return UnknownModelStatus
+	if ms, ok := highsModelStatuses[hms]; ok {
+		return ms
 	}
+	return UnknownModelStatus
 }
 
 //go:generate stringer -type=ModelStatus
@@ -131,4 +149,102 @@ var variableTypeToHighs = []C.HighsInt{
 	C.kHighsVarTypeImplicitInteger,
 }
 
+// convertHighsVarType converts a kHighsVarType to a VariableType.
+func convertHighsVarType(hvt C.HighsInt) (VariableType, error) {
+	for vt, h := range variableTypeToHighs {
+		if h == hvt {
+			return VariableType(vt), nil
+		}
+	}
+	return ContinuousType, fmt.Errorf("unrecognized variable type %d", hvt)
+}
+
 //go:generate stringer -type=VariableType
+
+// A SimplexCrash represents a heuristic the simplex solver can use to
+// construct its initial basis.
+type SimplexCrash int
+
+// These are the values a SimplexCrash accepts.  Each equals the
+// corresponding value of HiGHS's simplex_crash_strategy option.
+const (
+	SimplexCrashOff                    SimplexCrash = iota // No crash
+	SimplexCrashLTSSFK                                     // LTSSF crash with k-priority
+	SimplexCrashBixby                                      // Bixby's crash
+	SimplexCrashLTSSFPri                                   // LTSSF crash with priority
+	SimplexCrashLTSFK                                      // LTSF crash with k-priority
+	SimplexCrashLTSFPri                                    // LTSF crash with priority
+	SimplexCrashLTSF                                       // LTSF crash
+	SimplexCrashBixbyNoNonzeroColCosts                     // Bixby's crash ignoring nonzero column costs
+	SimplexCrashBasic                                      // Construct a basic basis
+	SimplexCrashTestSing                                   // Test for singularity
+)
+
+//go:generate stringer -type=SimplexCrash
+
+// A SimplexDualEdgeWeight represents a pricing strategy the dual simplex
+// solver can use to select the row to leave the basis.
+type SimplexDualEdgeWeight int
+
+// These are the values a SimplexDualEdgeWeight accepts.  The zero value lets
+// HiGHS choose a strategy.
+const (
+	SimplexDualEdgeWeightChoose SimplexDualEdgeWeight = iota
+	SimplexDualEdgeWeightDantzig
+	SimplexDualEdgeWeightDevex
+	SimplexDualEdgeWeightSteepestEdge
+)
+
+//go:generate stringer -type=SimplexDualEdgeWeight
+
+// An ObjSense indicates whether an objective function is to be minimized or
+// maximized.
+type ObjSense int
+
+// These are the values an ObjSense accepts:
+const (
+	Minimize ObjSense = iota
+	Maximize
+)
+
+//go:generate stringer -type=ObjSense
+
+// A ConstraintSense indicates how a row's activity is related to its
+// right-hand side when a model is expressed in the form Ax <sense> b.
+type ConstraintSense int
+
+// These are the values a ConstraintSense accepts:
+const (
+	ConstraintLessEqual    ConstraintSense = iota // Aᵢx ≤ bᵢ
+	ConstraintGreaterEqual                        // Aᵢx ≥ bᵢ
+	ConstraintEqual                               // Aᵢx = bᵢ
+	ConstraintRanged                              // RowLower[i] ≤ Aᵢx ≤ bᵢ
+	ConstraintFree                                // Aᵢx is unconstrained
+)
+
+//go:generate stringer -type=ConstraintSense
+
+// An OptionType indicates the type of value a HiGHS option accepts.
+type OptionType int
+
+// These are the values an OptionType accepts:
+const (
+	BoolOption    OptionType = iota // Set with SetBoolOption
+	IntOption                       // Set with SetIntOption
+	Float64Option                   // Set with SetFloat64Option
+	StringOption                    // Set with SetStringOption
+)
+
+//go:generate stringer -type=OptionType
+
+// A SanitizePolicy tells Sanitize what to do with a NaN or infinite
+// coefficient.
+type SanitizePolicy int
+
+// These are the values a SanitizePolicy accepts:
+const (
+	SanitizeRemove SanitizePolicy = iota // Remove the coefficient from the model
+	SanitizeError                        // Leave the model unmodified and return an error
+)
+
+//go:generate stringer -type=SanitizePolicy
@@ -3,7 +3,10 @@
 package highs
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math/big"
 	"sort"
 
 	"golang.org/x/exp/constraints"
@@ -50,6 +53,22 @@ func newCallStatus(st C.HighsInt, hName, gName string) error {
 	}
 }
 
+// ErrUnsupported indicates that an operation cannot be performed because
+// HiGHS provides no mechanism for it.
+var ErrUnsupported = errors.New("operation is not supported by HiGHS")
+
+// These errors classify solutions that are not optimal.  They can be tested
+// for with errors.Is on the result of RawSolution.Err.  Validate also returns
+// errors that wrap ErrInfeasible when it detects an infeasible model without
+// solving it.
+var (
+	ErrNotOptimal     = errors.New("solution is not optimal")
+	ErrInfeasible     = errors.New("model is infeasible")
+	ErrUnbounded      = errors.New("model is unbounded")
+	ErrTimeLimit      = errors.New("time limit reached")
+	ErrIterationLimit = errors.New("iteration limit reached")
+)
+
 // A numeric is any integer or any floating-point type.
 type numeric interface {
 	constraints.Integer | constraints.Float
@@ -69,6 +88,13 @@ func convertSlice[T, F numeric](from []F) []T {
 // tri is true, rejects lower-triangular elements.  filterNonzeros serves as a
 // helper function for nonzerosToCSR.
 func filterNonzeros(nz []Nonzero, tri bool) ([]Nonzero, error) {
+	return filterNonzerosContext(context.Background(), nz, tri)
+}
+
+// filterNonzerosContext is a variant of filterNonzeros that checks for
+// cancellation of a context before and after sorting and, if cancelled,
+// returns the context's error.
+func filterNonzerosContext(ctx context.Context, nz []Nonzero, tri bool) ([]Nonzero, error) {
 	// Complain about negative indices.
 	for _, v := range nz {
 		if v.Row < 0 || v.Col < 0 {
@@ -90,6 +116,9 @@ func filterNonzeros(nz []Nonzero, tri bool) ([]Nonzero, error) {
 	}
 
 	// Make a copy of the nonzeroes and sort the copy in place.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	sorted := make([]Nonzero, len(nz))
 	copy(sorted, nz)
 	sort.SliceStable(sorted, func(i, j int) bool {
@@ -108,6 +137,9 @@ func filterNonzeros(nz []Nonzero, tri bool) ([]Nonzero, error) {
 			return false // Equal coordinates
 		}
 	})
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	// Elide duplicate entries, keeping the latest value.
 	noDups := make([]Nonzero, 0, len(sorted))
@@ -131,19 +163,43 @@ func filterNonzeros(nz []Nonzero, tri bool) ([]Nonzero, error) {
 // nonzerosToCSR converts a list of Nonzero elements to a compressed sparse row
 // representation in the form of a set of C vectors accepted by the HiGHS APIs.
 func nonzerosToCSR(nz []Nonzero, tri bool) (start, index []C.HighsInt, value []C.double, err error) {
-	// Allocate memory for all of our return vectors.
+	return nonzerosToCSRContext(context.Background(), nz, tri, nil)
+}
+
+// ctxCheckInterval is the number of loop iterations between checks for
+// cancellation in long-running, context-aware loops.
+const ctxCheckInterval = 1 << 16
+
+// nonzerosToCSRContext is a variant of nonzerosToCSR that periodically checks
+// for cancellation of a context and, if cancelled, returns the context's
+// error.  If progress is non-nil, it is periodically passed the fraction of
+// the conversion that has been completed.
+func nonzerosToCSRContext(ctx context.Context, nz []Nonzero, tri bool, progress func(float64)) (start, index []C.HighsInt, value []C.double, err error) {
+	// Allocate memory for all of our return vectors.  Sorting accounts
+	// for roughly half of the conversion time.
 	var nonzeros []Nonzero
-	nonzeros, err = filterNonzeros(nz, tri)
+	nonzeros, err = filterNonzerosContext(ctx, nz, tri)
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	if progress != nil {
+		progress(0.5)
+	}
 	start = make([]C.HighsInt, 0, len(nonzeros))
 	index = make([]C.HighsInt, 0, len(nonzeros))
 	value = make([]C.double, 0, len(nonzeros))
 
 	// Construct slices of C types.
 	prevRow := -1
-	for _, nz := range nonzeros {
+	for i, nz := range nonzeros {
+		if i%ctxCheckInterval == 0 {
+			if err = ctx.Err(); err != nil {
+				return nil, nil, nil, err
+			}
+			if progress != nil && i > 0 {
+				progress(0.5 + 0.5*float64(i)/float64(len(nonzeros)))
+			}
+		}
 		if nz.Row > prevRow {
 			start = append(start, C.HighsInt(len(value)))
 			prevRow = nz.Row
@@ -151,6 +207,9 @@ func nonzerosToCSR(nz []Nonzero, tri bool) (start, index []C.HighsInt, value []C
 		index = append(index, C.HighsInt(nz.Col))
 		value = append(value, C.double(nz.Val))
 	}
+	if progress != nil {
+		progress(1.0)
+	}
 	return start, index, value, nil
 }
 
@@ -190,6 +249,14 @@ func expandToLen[T any](n int, xs []T, v T) ([]T, bool) {
 	}
 }
 
+// cloneSlice returns a copy of a slice.  It preserves nil slices.
+func cloneSlice[T any](xs []T) []T {
+	if xs == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(xs)), xs...)
+}
+
 // sliceToPointer returns a pointer to the first element of a slice or nil if
 // the slice is empty.
 func sliceToPointer[T any](xs []T) *T {
@@ -198,3 +265,46 @@ func sliceToPointer[T any](xs []T) *T {
 	}
 	return &xs[0]
 }
+
+// solveRational solves the square linear system Ax = b exactly by Gaussian
+// elimination.  A and b are overwritten.  solveRational returns false if A is
+// singular.
+func solveRational(a [][]*big.Rat, b []*big.Rat) ([]*big.Rat, bool) {
+	n := len(b)
+	tmp := new(big.Rat)
+	for k := 0; k < n; k++ {
+		// Find a row with a nonzero pivot, and swap it into place.
+		p := k
+		for p < n && a[p][k].Sign() == 0 {
+			p++
+		}
+		if p == n {
+			return nil, false
+		}
+		a[k], a[p] = a[p], a[k]
+		b[k], b[p] = b[p], b[k]
+
+		// Eliminate column k from all subsequent rows.
+		for i := k + 1; i < n; i++ {
+			if a[i][k].Sign() == 0 {
+				continue
+			}
+			f := new(big.Rat).Quo(a[i][k], a[k][k])
+			for j := k; j < n; j++ {
+				a[i][j].Sub(a[i][j], tmp.Mul(f, a[k][j]))
+			}
+			b[i].Sub(b[i], tmp.Mul(f, b[k]))
+		}
+	}
+
+	// Back-substitute.
+	x := make([]*big.Rat, n)
+	for i := n - 1; i >= 0; i-- {
+		sum := new(big.Rat).Set(b[i])
+		for j := i + 1; j < n; j++ {
+			sum.Sub(sum, tmp.Mul(a[i][j], x[j]))
+		}
+		x[i] = sum.Quo(sum, a[i][i])
+	}
+	return x, true
+}
@@ -46,3 +46,59 @@ func checkErr(t *testing.T, e error) {
 	// Fail on everything else.
 	t.Fatal(e)
 }
+
+// minimalAPIModel returns the model used by TestMinimalAPIMin and
+// TestMinimalAPIMax:
+//
+//	Min/max f  =  x_0 +  x_1 + 3
+//	s.t.                 x_1 <= 7
+//	        5 <=  x_0 + 2x_1 <= 15
+//	        6 <= 3x_0 + 2x_1
+//	0 <= x_0 <= 4; 1 <= x_1
+func minimalAPIModel(maximize bool) *Model {
+	var model Model
+	model.Maximize = maximize
+	model.Offset = 3.0
+	model.ColCosts = []float64{1.0, 1.0}
+	model.ColLower = []float64{0.0, 1.0}
+	model.ColUpper = []float64{4.0, 1.0e30}
+	model.RowLower = []float64{-1.0e30, 5.0, 6.0}
+	model.RowUpper = []float64{7.0, 15.0, 1.0e30}
+	model.ConstMatrix = []Nonzero{
+		{0, 1, 1.0},
+		{1, 0, 1.0},
+		{1, 1, 2.0},
+		{2, 0, 3.0},
+		{2, 1, 2.0},
+	}
+	return &model
+}
+
+// solveRaw converts a high-level model to a low-level model, solves it, and
+// returns the resulting RawSolution.  It aborts the test on error or if the
+// solution is not optimal.
+func solveRaw(t *testing.T, model *Model) *RawSolution {
+	raw, err := model.ToRawModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkErr(t, raw.SetBoolOption("output_flag", false))
+	soln, err := raw.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if soln.Status != Optimal {
+		t.Fatalf("Solve returned %s instead of Optimal", soln.Status)
+	}
+	return soln
+}
+
+// mustToRawModel converts a high-level model to a low-level model, aborting
+// the test on error.
+func mustToRawModel(t *testing.T, model *Model) *RawModel {
+	raw, err := model.ToRawModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}